@@ -5,6 +5,7 @@ import (
 	"github.com/lizongying/go-youtube/internal/config"
 	"github.com/lizongying/go-youtube/internal/logger"
 	"github.com/lizongying/go-youtube/internal/mongodb"
+	"github.com/lizongying/go-youtube/internal/proxyPool"
 	"github.com/lizongying/go-youtube/internal/youtubeSpider"
 	"go.uber.org/fx"
 	"golang.org/x/net/context"
@@ -23,19 +24,23 @@ func init() {
 	log.Println(info)
 }
 
-func search(youtube *youtubeSpider.YoutubeSpider) (err error) {
+func search(ctx context.Context, youtube *youtubeSpider.YoutubeSpider) (err error) {
 	for _, v := range []string{
 		"makeupvideo", "makeuptutorial", "beautytips", "beauty", "fashion", "fashionstyle", "fashiondiaries", "fashiontrends", "springfashion", "outfits", "ootd", "outfitoftheday",
 	} {
-		_ = youtube.Search(context.Background(), youtubeSpider.MetaSearch{
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			return
+		}
+		_ = youtube.Search(ctx, youtubeSpider.MetaSearch{
 			Keyword: v,
 		})
 	}
 	return
 }
 
-func searchApi(youtube *youtubeSpider.YoutubeSpider) (err error) {
-	err = youtube.SearchApi(context.Background(), youtubeSpider.MetaSearch{
+func searchApi(ctx context.Context, youtube *youtubeSpider.YoutubeSpider) (err error) {
+	err = youtube.SearchApi(ctx, youtubeSpider.MetaSearch{
 		Keyword:       "youtube",
 		Page:          1,
 		MaxPage:       2,
@@ -44,15 +49,15 @@ func searchApi(youtube *youtubeSpider.YoutubeSpider) (err error) {
 	return
 }
 
-func userApi(youtube *youtubeSpider.YoutubeSpider) (err error) {
-	err = youtube.UserApi(context.Background(), youtubeSpider.MetaUser{
+func userApi(ctx context.Context, youtube *youtubeSpider.YoutubeSpider) (err error) {
+	err = youtube.UserApi(ctx, youtubeSpider.MetaUser{
 		Key: "UCYJhto4Of0p8eKKxmB2un9g",
 	})
 	return
 }
 
-func videos(youtube *youtubeSpider.YoutubeSpider) (err error) {
-	err = youtube.Videos(context.Background(), youtubeSpider.MetaUser{
+func videos(ctx context.Context, youtube *youtubeSpider.YoutubeSpider) (err error) {
+	err = youtube.Videos(ctx, youtubeSpider.MetaUser{
 		Id: "sierramarie",
 	})
 	return
@@ -64,20 +69,40 @@ func main() {
 			config.NewConfig,
 			mongodb.NewMongoDb,
 			logger.NewLogger,
+			proxyPool.NewProxyPool,
 			youtubeSpider.NewYoutubeSpider,
 		),
-		fx.Invoke(func(youtube *youtubeSpider.YoutubeSpider, shutdowner fx.Shutdowner) (err error) {
-			err = search(youtube)
-			if err != nil {
-				return
-			}
-
-			err = shutdowner.Shutdown()
-			if err != nil {
-				return
-			}
+		fx.Invoke(func(lc fx.Lifecycle, logger *logger.Logger, youtube *youtubeSpider.YoutubeSpider, shutdowner fx.Shutdowner) {
+			// the crawl runs outside the start hook so that SIGTERM cancels it
+			// and the stop hook waits for the in-flight request or write to return
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			lc.Append(fx.Hook{
+				OnStart: func(context.Context) (err error) {
+					go func() {
+						defer close(done)
+						e := search(ctx, youtube)
+						if e != nil {
+							logger.Error(e)
+						}
 
-			return
+						e = shutdowner.Shutdown()
+						if e != nil {
+							logger.Error(e)
+						}
+					}()
+					return
+				},
+				OnStop: func(stopCtx context.Context) (err error) {
+					cancel()
+					select {
+					case <-done:
+					case <-stopCtx.Done():
+						err = stopCtx.Err()
+					}
+					return
+				},
+			})
 		}),
 	).Run()
 }
@@ -1,6 +1,6 @@
 module github.com/lizongying/go-youtube
 
-go 1.20
+go 1.22
 
 require (
 	go.mongodb.org/mongo-driver v1.11.3
@@ -5,6 +5,7 @@ import (
 	"gopkg.in/yaml.v2"
 	"log"
 	"os"
+	"time"
 )
 
 type Config struct {
@@ -23,8 +24,94 @@ type Config struct {
 		Key string `yaml:"key" json:"-"`
 	} `yaml:"youtube" json:"-"`
 	Proxy struct {
-		Example string `yaml:"example" json:"-"`
+		Example     string        `yaml:"example" json:"-"`
+		List        []string      `yaml:"list" json:"-"`
+		Rotation    string        `yaml:"rotation" json:"-"`
+		MaxFailures int           `yaml:"max_failures" json:"-"`
+		Quarantine  time.Duration `yaml:"quarantine" json:"-"`
+		CheckUrl    string        `yaml:"check_url" json:"-"`
 	} `yaml:"proxy" json:"-"`
+	Spider struct {
+		BaseUrl    string `yaml:"base_url" json:"-"`
+		ApiBaseUrl string `yaml:"api_base_url" json:"-"`
+		Fetcher    struct {
+			Mode string `yaml:"mode" json:"-"`
+			Dir  string `yaml:"dir" json:"-"`
+		} `yaml:"fetcher" json:"-"`
+		Pool struct {
+			Workers     int `yaml:"workers" json:"-"`
+			MaxInFlight int `yaml:"max_in_flight" json:"-"`
+		} `yaml:"pool" json:"-"`
+		Limit struct {
+			Rpm     float64       `yaml:"rpm" json:"-"`
+			Burst   int           `yaml:"burst" json:"-"`
+			Jitter  time.Duration `yaml:"jitter" json:"-"`
+			Search  Rate          `yaml:"search" json:"-"`
+			Browse  Rate          `yaml:"browse" json:"-"`
+			Channel Rate          `yaml:"channel" json:"-"`
+		} `yaml:"limit" json:"-"`
+		Retry struct {
+			MaxAttempts int           `yaml:"max_attempts" json:"-"`
+			BaseDelay   time.Duration `yaml:"base_delay" json:"-"`
+			MaxDelay    time.Duration `yaml:"max_delay" json:"-"`
+		} `yaml:"retry" json:"-"`
+		Innertube struct {
+			Ttl time.Duration `yaml:"ttl" json:"-"`
+			Hl  string        `yaml:"hl" json:"-"`
+			Gl  string        `yaml:"gl" json:"-"`
+		} `yaml:"innertube" json:"-"`
+		Comments struct {
+			Videos  int  `yaml:"videos" json:"-"`
+			MaxPage int  `yaml:"max_page" json:"-"`
+			Replies bool `yaml:"replies" json:"-"`
+		} `yaml:"comments" json:"-"`
+		Videos struct {
+			All      bool          `yaml:"all" json:"-"`
+			MaxCount int           `yaml:"max_count" json:"-"`
+			MaxAge   time.Duration `yaml:"max_age" json:"-"`
+		} `yaml:"videos" json:"-"`
+		Shorts struct {
+			Enabled bool `yaml:"enabled" json:"-"`
+		} `yaml:"shorts" json:"-"`
+		Streams struct {
+			Enabled bool `yaml:"enabled" json:"-"`
+		} `yaml:"streams" json:"-"`
+		Playlists struct {
+			MaxPage int `yaml:"max_page" json:"-"`
+		} `yaml:"playlists" json:"-"`
+		Dedup struct {
+			Freshness time.Duration `yaml:"freshness" json:"-"`
+		} `yaml:"dedup" json:"-"`
+		Rules *Rules `yaml:"rules" json:"-"`
+	} `yaml:"spider" json:"-"`
+}
+
+// Rate requests per minute, with bursts of up to burst. A rpm of 0 means no limit.
+type Rate struct {
+	Rpm   float64 `yaml:"rpm" json:"-"`
+	Burst int     `yaml:"burst" json:"-"`
+}
+
+// Rules the channels a job keeps. A bound of 0 means no bound, an empty list means any.
+// A channel qualifies by the view average of its videos, shorts or streams, whichever is within bounds.
+type Rules struct {
+	MinFollowers int           `yaml:"min_followers" json:"min_followers,omitempty"`
+	MaxFollowers int           `yaml:"max_followers" json:"max_followers,omitempty"`
+	MinViewAvg   int           `yaml:"min_view_avg" json:"min_view_avg,omitempty"`
+	MaxViewAvg   int           `yaml:"max_view_avg" json:"max_view_avg,omitempty"`
+	Recency      time.Duration `yaml:"recency" json:"recency,omitempty"`
+	RequireEmail bool          `yaml:"require_email" json:"require_email,omitempty"`
+	Languages    []string      `yaml:"languages" json:"languages,omitempty"`
+	Countries    []string      `yaml:"countries" json:"countries,omitempty"`
+}
+
+// DefaultRules the rules of a config that leaves them out, those of before rules were configurable
+func DefaultRules() *Rules {
+	return &Rules{
+		MinViewAvg: 1000,
+		MaxViewAvg: 100000,
+		Recency:    time.Hour * 24 * 90,
+	}
 }
 
 func (c *Config) LoadConfig(configPath string) (err error) {
@@ -54,6 +141,9 @@ func NewConfig() (config *Config, err error) {
 			log.Panicln(err)
 		}
 	}
+	if config.Spider.Rules == nil {
+		config.Spider.Rules = DefaultRules()
+	}
 
 	return
 }
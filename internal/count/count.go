@@ -0,0 +1,184 @@
+package count
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/language"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var numberRe = regexp.MustCompile(`\d+(?:[.,'\x{a0}\x{202f} ]\d+)*`)
+
+// units the abbreviations of a language, lowercase, by the multiplier they stand for
+var units = map[string]map[string]float64{
+	"en": {"k": 1e3, "m": 1e6, "b": 1e9, "g": 1e9, "t": 1e12, "lakh": 1e5, "crore": 1e7},
+	"de": {"tsd": 1e3, "mio": 1e6, "mrd": 1e9},
+	"fr": {"k": 1e3, "m": 1e6, "md": 1e9, "mrd": 1e9},
+	"es": {"k": 1e3, "mil": 1e3, "m": 1e6, "mil m": 1e9},
+	"pt": {"k": 1e3, "mil": 1e3, "mi": 1e6, "bi": 1e9},
+	"it": {"k": 1e3, "mila": 1e3, "mln": 1e6, "mrd": 1e9},
+	"nl": {"k": 1e3, "mln": 1e6, "mld": 1e9},
+	"sv": {"tn": 1e3, "mn": 1e6, "md": 1e9},
+	"da": {"t": 1e3, "mio": 1e6, "mia": 1e9},
+	"nb": {"k": 1e3, "mill": 1e6, "mrd": 1e9},
+	"fi": {"t": 1e3, "milj": 1e6, "mrd": 1e9},
+	"pl": {"tys": 1e3, "mln": 1e6, "mld": 1e9},
+	"cs": {"tis": 1e3, "mil": 1e6, "mld": 1e9},
+	"sk": {"tis": 1e3, "mil": 1e6, "mld": 1e9},
+	"sl": {"tis": 1e3, "mio": 1e6, "mrd": 1e9},
+	"hr": {"tis": 1e3, "mil": 1e6, "mlr": 1e9},
+	"bs": {"hilj": 1e3, "mil": 1e6, "mlr": 1e9},
+	"sr": {"хиљ": 1e3, "мил": 1e6, "млрд": 1e9, "hilj": 1e3, "mil": 1e6, "mlrd": 1e9},
+	"bg": {"хил": 1e3, "млн": 1e6, "млрд": 1e9},
+	"lt": {"tūkst": 1e3, "mln": 1e6, "mlrd": 1e9},
+	"lv": {"tūkst": 1e3, "milj": 1e6, "mljrd": 1e9},
+	"et": {"tuh": 1e3, "mln": 1e6, "mld": 1e9},
+	"ro": {"k": 1e3, "mii": 1e3, "mil": 1e6, "mld": 1e9},
+	"hu": {"e": 1e3, "m": 1e6, "mrd": 1e9},
+	"tr": {"b": 1e3, "mn": 1e6, "mr": 1e9, "milyon": 1e6, "milyar": 1e9},
+	"ru": {"тыс": 1e3, "млн": 1e6, "млрд": 1e9},
+	"uk": {"тис": 1e3, "млн": 1e6, "млрд": 1e9},
+	"el": {"χιλ": 1e3, "εκ": 1e6, "δισ": 1e9},
+	"id": {"rb": 1e3, "jt": 1e6, "m": 1e9},
+	"ms": {"k": 1e3, "j": 1e6, "b": 1e9},
+	"vi": {"n": 1e3, "nghìn": 1e3, "tr": 1e6, "triệu": 1e6, "t": 1e9, "tỷ": 1e9},
+	"th": {"พัน": 1e3, "หมื่น": 1e4, "แสน": 1e5, "ล้าน": 1e6},
+	"hi": {"हज़ार": 1e3, "लाख": 1e5, "क॰": 1e7, "करोड़": 1e7, "अरब": 1e9},
+	"ar": {"ألف": 1e3, "آلاف": 1e3, "مليون": 1e6, "مليار": 1e9},
+	"fa": {"هزار": 1e3, "میلیون": 1e6, "میلیارد": 1e9},
+	"bn": {"হাজার": 1e3, "হা": 1e3, "লাখ": 1e5, "লা": 1e5, "কোটি": 1e7, "কো": 1e7},
+	"he": {"k": 1e3, "m": 1e6, "b": 1e9, "אלף": 1e3, "מיליון": 1e6},
+}
+
+// cjkUnits the units of Chinese, Japanese and Korean, the same whatever the language and not followed by a space
+var cjkUnits = map[string]float64{
+	"千": 1e3, "万": 1e4, "萬": 1e4, "亿": 1e8, "億": 1e8,
+	"천": 1e3, "만": 1e4, "억": 1e8,
+}
+
+// zeros the words of "No views", "No subscribers", in any language
+var zeros = []string{
+	"no ", "keine", "aucun", "sin ", "nenhum", "nessun", "geen", "inga", "ingen", "ei ", "brak", "žádn", "niciun",
+	"hiç", "нет", "немає", "καμία", "tidak ada", "belum ada", "chưa có", "ไม่มี", "कोई", "لا ", "אין",
+	"なし", "无", "無", "없음",
+}
+
+// sortedUnits the units of each language, longest first so that "mil m" wins over "mil" and "m"
+var sortedUnits = language.SortedWords(units)
+
+// normalize digits and separators of other scripts to ascii
+func normalize(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '٠' && r <= '٩':
+			return '0' + r - '٠'
+		case r >= '۰' && r <= '۹':
+			return '0' + r - '۰'
+		case r >= '০' && r <= '৯':
+			return '0' + r - '০'
+		case r == '٫':
+			return '.'
+		case r == '٬':
+			return ','
+		}
+		return r
+	}, text)
+}
+
+// multiplier the multiplier of the unit rest starts with in the first of langs that has one, 1 if there is none
+func multiplier(rest string, langs []string) (m float64) {
+	for _, l := range langs {
+		m = unitMultiplier(rest, l)
+		if m != 1 {
+			return
+		}
+	}
+	return
+}
+
+// unitMultiplier the multiplier of the unit of the language l rest starts with, 1 if there is none
+func unitMultiplier(rest string, l string) float64 {
+	rest = strings.ToLower(strings.TrimLeft(rest, " \u00a0\u202f"))
+
+	for k, v := range cjkUnits {
+		if strings.HasPrefix(rest, k) {
+			return v
+		}
+	}
+
+	for _, k := range sortedUnits[l] {
+		if !strings.HasPrefix(rest, k) {
+			continue
+		}
+		// a whole word only, the m of "1 month" is no unit
+		r, _ := utf8.DecodeRuneInString(rest[len(k):])
+		if r != utf8.RuneError && (unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)) {
+			continue
+		}
+		return units[l][k]
+	}
+	return 1
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// Parse a count as YouTube shows it in the language hl, e.g. "1.2M subscribers", "1,2 k abonnés",
+// "12万 位订阅者", "1.5 Mio. Abonnenten", "1,234,567 views" or "No views".
+// The first number of text is parsed, with the unit that follows it.
+// A decimal number without a unit known, like the "1,2" of a language without units here, is an error
+func Parse(text string, hl string) (count int, err error) {
+	text = normalize(text)
+	langs := language.Langs(hl)
+
+	loc := numberRe.FindStringIndex(text)
+	if loc == nil {
+		lower := strings.ToLower(text) + " "
+		for _, v := range zeros {
+			if strings.Contains(lower, v) {
+				return
+			}
+		}
+		err = fmt.Errorf("no count in %q", text)
+		return
+	}
+
+	number := text[loc[0]:loc[1]]
+	m := multiplier(text[loc[1]:], langs)
+
+	// whole counts have no decimals, every separator groups.
+	// Abbreviated counts are not grouped, their last separator is the decimal one
+	digits := onlyDigits(number)
+	i := strings.LastIndexAny(number, ".,'\u00a0\u202f ")
+	switch {
+	case m > 1 && i >= 0:
+		digits = onlyDigits(number[:i]) + "." + onlyDigits(number[i+1:])
+	case i >= 0 && len(onlyDigits(number[i:])) != 3:
+		// the "1,2" of a unit not known, groups of thousands have 3 digits
+		err = fmt.Errorf("no unit known for the decimal count %q", text)
+		return
+	}
+
+	f, err := strconv.ParseFloat(digits, 64)
+	if err != nil {
+		return
+	}
+	f = math.Round(f * m)
+	if f > math.MaxInt64 {
+		err = errors.New("count out of range")
+		return
+	}
+	count = int(f)
+	return
+}
@@ -0,0 +1,126 @@
+package count
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	for _, v := range []struct {
+		hl    string
+		text  string
+		count int
+	}{
+		{"en", "1.2M subscribers", 1200000},
+		{"en", "1.2B views", 1200000000},
+		{"en", "12.3K views", 12300},
+		{"en", "987 views", 987},
+		{"en", "1,234,567 views", 1234567},
+		{"en", "1 view", 1},
+		{"en", "No views", 0},
+		{"en", "No subscribers", 0},
+		{"en", "3 months ago", 3},
+		{"en-GB", "4.5K watching", 4500},
+		{"en-IN", "1.2 lakh views", 120000},
+		{"", "1.2M", 1200000},
+		{"de", "1,5 Mio. Abonnenten", 1500000},
+		{"de", "12.345 Aufrufe", 12345},
+		{"de", "2,1 Mrd. Aufrufe", 2100000000},
+		{"de", "Keine Aufrufe", 0},
+		{"fr", "1,2 k abonnés", 1200},
+		{"fr", "3,4 M de vues", 3400000},
+		{"fr", "1 234 567 vues", 1234567},
+		{"fr", "1 234 vues", 1234},
+		{"fr", "Aucune vue", 0},
+		{"es", "1,2 mil suscriptores", 1200},
+		{"es", "3,4 M de visualizaciones", 3400000},
+		{"es", "1,2 mil M de visualizaciones", 1200000000},
+		{"es-419", "12 k suscriptores", 12000},
+		{"es", "Sin visualizaciones", 0},
+		{"pt-BR", "1,2 mil inscritos", 1200},
+		{"pt-BR", "3,4 mi de visualizações", 3400000},
+		{"pt-BR", "1,1 bi de visualizações", 1100000000},
+		{"pt-PT", "Nenhuma visualização", 0},
+		{"it", "1,2 Mln di iscritti", 1200000},
+		{"it", "12.345 visualizzazioni", 12345},
+		{"it", "Nessuna visualizzazione", 0},
+		{"nl", "1,2 mln. abonnees", 1200000},
+		{"nl", "Geen weergaven", 0},
+		{"sv", "1,2 tn prenumeranter", 1200},
+		{"da", "1,2 mio. abonnenter", 1200000},
+		{"nb", "1,2 mill. abonnenter", 1200000},
+		{"no", "3,4 mrd. avspillinger", 3400000000},
+		{"fi", "1,2 milj. tilaajaa", 1200000},
+		{"pl", "1,2 tys. subskrybentów", 1200},
+		{"pl", "3,4 mln wyświetleń", 3400000},
+		{"pl", "Brak wyświetleń", 0},
+		{"cs", "1,2 tis. odběratelů", 1200},
+		{"ro", "1,2 mil. de abonați", 1200000},
+		{"hu", "1,2 E feliratkozó", 1200},
+		{"tr", "1,2 B abone", 1200},
+		{"tr", "3,4 Mn görüntüleme", 3400000},
+		{"ru", "1,2 тыс. подписчиков", 1200},
+		{"ru", "3,4 млн просмотров", 3400000},
+		{"ru", "1 млрд просмотров", 1000000000},
+		{"ru", "Нет просмотров", 0},
+		{"uk", "1,2 тис. підписників", 1200},
+		{"el", "1,2 εκ. συνδρομητές", 1200000},
+		{"id", "1,2 rb subscriber", 1200},
+		{"id", "3,4 jt x ditonton", 3400000},
+		{"id", "1,2 M x ditonton", 1200000000},
+		{"ms", "1.2J tontonan", 1200000},
+		{"vi", "1,2 N người đăng ký", 1200},
+		{"vi", "3,4 Tr lượt xem", 3400000},
+		{"th", "ผู้ติดตาม 1.2 แสน คน", 120000},
+		{"hi", "1.2 लाख सब्सक्राइबर", 120000},
+		{"hi", "1 क॰ व्यूज़", 10000000},
+		{"ar", "١٫٢ مليون مشترك", 1200000},
+		{"ja", "チャンネル登録者数 12万人", 120000},
+		{"ja", "1.2億 回視聴", 120000000},
+		{"ja", "視聴回数なし", 0},
+		{"zh-CN", "12万 位订阅者", 120000},
+		{"zh-CN", "3.4亿次观看", 340000000},
+		{"zh-TW", "1.2萬 位訂閱者", 12000},
+		{"zh-TW", "1.2千 次觀看", 1200},
+		{"ko", "구독자 1.2만명", 12000},
+		{"ko", "조회수 3.4억회", 340000000},
+		{"ko", "조회수 없음", 0},
+		{"bg", "1,2 хил. абонати", 1200},
+		{"sk", "1,2 tis. odberateľov", 1200},
+		{"sl", "1,2 mio. naročnikov", 1200000},
+		{"hr", "1,2 tis. pretplatnika", 1200},
+		{"sr", "1,2 хиљ. пратилаца", 1200},
+		{"lt", "1,2 tūkst. prenumeratorių", 1200},
+		{"lv", "1,2 milj. abonentu", 1200000},
+		{"et", "1,2 tuh tellijat", 1200},
+		{"fa", "۱٫۲ هزار مشترک", 1200},
+		{"bn", "১.২ লাখ জন সদস্য", 120000},
+		// pages in English whatever the language asked for
+		{"de", "1.2K views", 1200},
+		{"ru", "3.4M views", 3400000},
+	} {
+		count, err := Parse(v.text, v.hl)
+		if err != nil {
+			t.Errorf("%s %q: %v", v.hl, v.text, err)
+			continue
+		}
+		if count != v.count {
+			t.Errorf("%s %q: %d, want %d", v.hl, v.text, count, v.count)
+		}
+	}
+}
+
+func TestParseNoCount(t *testing.T) {
+	for _, v := range []struct {
+		hl   string
+		text string
+	}{
+		{"en", ""},
+		{"en", "views"},
+		{"en", "Subscribe"},
+		// a decimal count with a unit not known is no count of 12
+		{"sw", "1,2 elfu wanaofuatilia"},
+		{"xx", "3.45 qq"},
+	} {
+		if count, err := Parse(v.text, v.hl); err == nil {
+			t.Errorf("%s %q: %d, want an error", v.hl, v.text, count)
+		}
+	}
+}
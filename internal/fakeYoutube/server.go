@@ -0,0 +1,244 @@
+package fakeYoutube
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/config"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+const (
+	ApiKey        = "fake-api-key"
+	VisitorData   = "fake-visitor-data"
+	ClientVersion = "2.20230327.01.00"
+)
+
+// Server an in-process stand-in for the youtube web pages and innertube api.
+//
+// Fixtures are looked up by request:
+//
+//	GET  /                             home.json
+//	GET  /results?search_query=q       results/<q>.json
+//	GET  /@handle/<tab>                channels/<handle>/<tab>.json
+//	GET  /channel/<id>/<tab>           channels/<id>/<tab>.json
+//	POST /youtubei/v1/*  continuation  continuation/<token>.json
+//	POST /youtubei/v1/*  browseId      browse/<browseId>.json
+//	                     and params    browse/<browseId>_<query escaped params>.json
+//	POST /youtubei/v1/x  videoId       x/<videoId>.json, e.g. player/<videoId>.json
+//	POST /youtubei/v1/*  query         search/<query>.json
+//
+// Html pages wrap the fixture as ytInitialData, next to a ytcfg holding ApiKey.
+type Server struct {
+	*httptest.Server
+
+	fixtures fs.FS
+
+	mu       sync.Mutex
+	requests []string
+	failures map[string][]int
+}
+
+// Fail answer the next n requests to path with status,
+// to exercise the spider's retries
+func (s *Server) Fail(path string, status int, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		s.failures[path] = append(s.failures[path], status)
+	}
+}
+
+func (s *Server) failure(path string) (status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := s.failures[path]
+	if len(statuses) == 0 {
+		return
+	}
+	status = statuses[0]
+	s.failures[path] = statuses[1:]
+	return
+}
+
+// Requests return the requests served so far, as "METHOD /path"
+func (s *Server) Requests() (requests []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests = append(requests, s.requests...)
+	return
+}
+
+func (s *Server) log(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) readFixture(name string) (bs []byte, err error) {
+	bs, err = fs.ReadFile(s.fixtures, name)
+	if err != nil {
+		return
+	}
+
+	// ytInitialData is matched up to the end of the line, so keep it on one
+	var buf bytes.Buffer
+	err = json.Compact(&buf, bs)
+	if err != nil {
+		return
+	}
+	bs = buf.Bytes()
+	return
+}
+
+func (s *Server) writeHtml(w http.ResponseWriter, name string) {
+	bs, err := s.readFixture(name)
+	if err != nil {
+		http.NotFound(w, nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, `<!DOCTYPE html><html><head>
+<script>ytcfg.set({"INNERTUBE_API_KEY":"%s","INNERTUBE_CONTEXT_CLIENT_NAME":1,"VISITOR_DATA":"%s"});</script>
+<script>ytcfg.set({"INNERTUBE_CONTEXT":{"client":{"hl":"en","gl":"US","clientName":"WEB","clientVersion":"%s"}}});</script>
+</head><body>
+<script>var ytInitialData = %s;</script>
+</body></html>`, ApiKey, VisitorData, ClientVersion, bs)
+}
+
+func (s *Server) writeJson(w http.ResponseWriter, name string) {
+	bs, err := s.readFixture(name)
+	if err != nil {
+		http.NotFound(w, nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write(bs)
+}
+
+func (s *Server) handleResults(w http.ResponseWriter, r *http.Request) {
+	s.writeHtml(w, path.Join("results", r.URL.Query().Get("search_query")+".json"))
+}
+
+func (s *Server) handleChannel(w http.ResponseWriter, r *http.Request) {
+	p := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/channel/"), "/@")
+	handle, tab, ok := strings.Cut(p, "/")
+	if !ok || handle == "" || tab == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.writeHtml(w, path.Join("channels", handle, tab+".json"))
+}
+
+func (s *Server) handleApi(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if r.URL.Query().Get("key") != ApiKey {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	bs, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		Continuation string `json:"continuation"`
+		BrowseId     string `json:"browseId"`
+		Params       string `json:"params"`
+		Query        string `json:"query"`
+		VideoId      string `json:"videoId"`
+	}
+	err = json.Unmarshal(bs, &body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case body.Continuation != "":
+		s.writeJson(w, path.Join("continuation", body.Continuation+".json"))
+	case body.BrowseId != "" && body.Params != "":
+		s.writeJson(w, path.Join("browse", body.BrowseId+"_"+url.QueryEscape(body.Params)+".json"))
+	case body.BrowseId != "":
+		s.writeJson(w, path.Join("browse", body.BrowseId+".json"))
+	case body.VideoId != "":
+		s.writeJson(w, path.Join(path.Base(r.URL.Path), body.VideoId+".json"))
+	case body.Query != "":
+		s.writeJson(w, path.Join("search", body.Query+".json"))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+// Configure point the spider at the server
+func (s *Server) Configure(c *config.Config) {
+	c.Spider.BaseUrl = s.URL
+	c.Spider.ApiBaseUrl = s.URL
+	c.Spider.Fetcher.Mode = ""
+	c.Proxy.Example = ""
+	c.Proxy.List = nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.log(r)
+
+	if status := s.failure(r.URL.Path); status != 0 {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/":
+		s.writeHtml(w, "home.json")
+	case r.URL.Path == "/results":
+		s.handleResults(w, r)
+	case strings.HasPrefix(r.URL.Path, "/@") || strings.HasPrefix(r.URL.Path, "/channel/"):
+		s.handleChannel(w, r)
+	case strings.HasPrefix(r.URL.Path, "/youtubei/v1/"):
+		s.handleApi(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// NewServer start a server on the embedded fixtures.
+// Close it when done.
+func NewServer() (server *Server) {
+	fsys, _ := fs.Sub(fixtures, "fixtures")
+	server = NewServerWithFixtures(fsys)
+	return
+}
+
+// NewServerWithFixtures start a server on fsys, laid out like the embedded fixtures dir.
+// Close it when done.
+func NewServerWithFixtures(fsys fs.FS) (server *Server) {
+	server = &Server{
+		fixtures: fsys,
+		failures: make(map[string][]int),
+	}
+	server.Server = httptest.NewServer(server)
+	return
+}
@@ -0,0 +1,69 @@
+package fetcher
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"net/http"
+)
+
+// Fetcher sends a request and returns the response, like http.Client.Do
+type Fetcher interface {
+	Do(req *http.Request) (resp *http.Response, err error)
+}
+
+// HttpFetcher fetch over the network
+type HttpFetcher struct {
+	client *http.Client
+}
+
+func (f *HttpFetcher) Do(req *http.Request) (resp *http.Response, err error) {
+	resp, err = f.client.Do(req)
+	return
+}
+
+func NewHttpFetcher(client *http.Client) (fetcher *HttpFetcher) {
+	fetcher = &HttpFetcher{
+		client: client,
+	}
+	return
+}
+
+// Exchange a request/response pair as stored on disk
+type Exchange struct {
+	Method       string      `json:"method"`
+	Url          string      `json:"url"`
+	RequestBody  string      `json:"request_body"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	ResponseBody string      `json:"response_body"`
+}
+
+// readRequestBody read the body and put it back, so the request can still be sent
+func readRequestBody(req *http.Request) (body []byte, err error) {
+	if req.Body == nil {
+		return
+	}
+
+	body, err = io.ReadAll(req.Body)
+	if err != nil {
+		return
+	}
+	_ = req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return
+}
+
+// key identify a request by method, path, query and body.
+// The host is left out, so recordings can be replayed against any base url.
+func key(req *http.Request, body []byte) string {
+	h := sha1.New()
+	h.Write([]byte(req.Method))
+	h.Write([]byte(" "))
+	h.Write([]byte(req.URL.RequestURI()))
+	h.Write([]byte("\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,34 @@
+package fetcher
+
+import (
+	"net/http"
+)
+
+// Waiter block until a request may be sent
+type Waiter interface {
+	Wait(req *http.Request) error
+}
+
+// LimitFetcher wait for the waiter before every request
+type LimitFetcher struct {
+	fetcher Fetcher
+	waiter  Waiter
+}
+
+func (f *LimitFetcher) Do(req *http.Request) (resp *http.Response, err error) {
+	err = f.waiter.Wait(req)
+	if err != nil {
+		return
+	}
+
+	resp, err = f.fetcher.Do(req)
+	return
+}
+
+func NewLimitFetcher(fetcher Fetcher, waiter Waiter) (limitFetcher *LimitFetcher) {
+	limitFetcher = &LimitFetcher{
+		fetcher: fetcher,
+		waiter:  waiter,
+	}
+	return
+}
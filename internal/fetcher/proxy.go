@@ -0,0 +1,42 @@
+package fetcher
+
+import (
+	"net/http"
+)
+
+// Router pick the route for a request and learn from its outcome
+type Router interface {
+	Route(req *http.Request) (*http.Request, error)
+	Report(req *http.Request, err error)
+}
+
+// ProxyFetcher route every request, and report whether it went through
+type ProxyFetcher struct {
+	fetcher Fetcher
+	router  Router
+}
+
+func (f *ProxyFetcher) Do(req *http.Request) (resp *http.Response, err error) {
+	req, err = f.router.Route(req)
+	if err != nil {
+		return
+	}
+
+	resp, err = f.fetcher.Do(req)
+	if err != nil {
+		f.router.Report(req, err)
+		return
+	}
+
+	// the status is classified again by the caller
+	f.router.Report(req, Classify(resp))
+	return
+}
+
+func NewProxyFetcher(fetcher Fetcher, router Router) (proxyFetcher *ProxyFetcher) {
+	proxyFetcher = &ProxyFetcher{
+		fetcher: fetcher,
+		router:  router,
+	}
+	return
+}
@@ -0,0 +1,68 @@
+package fetcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// RecordFetcher fetch with another fetcher and write every exchange to dir
+type RecordFetcher struct {
+	fetcher Fetcher
+	dir     string
+}
+
+func (f *RecordFetcher) Do(req *http.Request) (resp *http.Response, err error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return
+	}
+
+	resp, err = f.fetcher.Do(req)
+	if err != nil {
+		return
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	exchange := Exchange{
+		Method:       req.Method,
+		Url:          req.URL.String(),
+		RequestBody:  string(reqBody),
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header,
+		ResponseBody: string(respBody),
+	}
+	bs, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return
+	}
+
+	err = os.WriteFile(filepath.Join(f.dir, key(req, reqBody)+".json"), bs, 0644)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+func NewRecordFetcher(fetcher Fetcher, dir string) (recordFetcher *RecordFetcher, err error) {
+	err = os.MkdirAll(dir, 0744)
+	if err != nil {
+		return
+	}
+
+	recordFetcher = &RecordFetcher{
+		fetcher: fetcher,
+		dir:     dir,
+	}
+	return
+}
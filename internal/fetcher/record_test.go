@@ -0,0 +1,127 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/lizongying/go-youtube/internal/fakeYoutube"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type exchangeRequest struct {
+	method string
+	path   string
+	body   string
+}
+
+func (r exchangeRequest) new(t *testing.T, baseUrl string) *http.Request {
+	t.Helper()
+
+	var body io.Reader
+	if r.body != "" {
+		body = strings.NewReader(r.body)
+	}
+	req, err := http.NewRequest(r.method, baseUrl+r.path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func readResponse(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bs)
+}
+
+func TestRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	server := fakeYoutube.NewServer()
+
+	requests := []exchangeRequest{
+		{http.MethodGet, "/@alice/videos", ""},
+		{http.MethodGet, "/@nobody/videos", ""},
+		{http.MethodPost, "/youtubei/v1/browse?key=" + fakeYoutube.ApiKey, `{"browseId":"UCalice"}`},
+		// the same url with another body is another exchange
+		{http.MethodPost, "/youtubei/v1/browse?key=" + fakeYoutube.ApiKey, `{"browseId":"VLPLbeauty"}`},
+	}
+
+	recordFetcher, err := NewRecordFetcher(NewHttpFetcher(http.DefaultClient), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type recorded struct {
+		status int
+		body   string
+	}
+	var want []recorded
+	for _, v := range requests {
+		resp, err := recordFetcher.Do(v.new(t, server.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, recorded{resp.StatusCode, readResponse(t, resp)})
+	}
+	server.Close()
+
+	if want[0].status != http.StatusOK || want[1].status != http.StatusNotFound || want[2].body == want[3].body {
+		t.Fatalf("recorded %+v", want)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(requests) {
+		t.Fatalf("%d files, want %d", len(files), len(requests))
+	}
+	bs, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exchange Exchange
+	err = json.Unmarshal(bs, &exchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exchange.Method == "" || exchange.Url == "" || exchange.StatusCode == 0 {
+		t.Errorf("exchange on disk %+v", exchange)
+	}
+
+	// the host is not part of the key, any base url replays
+	replayFetcher, err := NewReplayFetcher(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range requests {
+		resp, err := replayFetcher.Do(v.new(t, "http://replay.invalid"))
+		if err != nil {
+			t.Fatalf("%s %s: %v", v.method, v.path, err)
+		}
+		if got := (recorded{resp.StatusCode, readResponse(t, resp)}); got != want[i] {
+			t.Errorf("%s %s: %d %q, want %d %q", v.method, v.path, got.status, got.body, want[i].status, want[i].body)
+		}
+	}
+
+	for _, v := range []exchangeRequest{
+		{http.MethodGet, "/@bob/videos", ""},
+		{http.MethodPost, "/@alice/videos", ""},
+		{http.MethodPost, "/youtubei/v1/browse?key=" + fakeYoutube.ApiKey, `{"browseId":"UCbob"}`},
+	} {
+		_, err = replayFetcher.Do(v.new(t, "http://replay.invalid"))
+		if !errors.Is(err, ErrNotRecorded) {
+			t.Errorf("%s %s: %v, want ErrNotRecorded", v.method, v.path, err)
+		}
+	}
+}
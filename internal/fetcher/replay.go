@@ -0,0 +1,78 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRecorded the request has no recording in the replay dir
+var ErrNotRecorded = errors.New("not recorded")
+
+// ReplayFetcher serve exchanges written by RecordFetcher, without network access
+type ReplayFetcher struct {
+	dir string
+}
+
+func (f *ReplayFetcher) Do(req *http.Request) (resp *http.Response, err error) {
+	if err = req.Context().Err(); err != nil {
+		return
+	}
+
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return
+	}
+
+	bs, err := os.ReadFile(filepath.Join(f.dir, key(req, reqBody)+".json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fmt.Errorf("%w: %s %s", ErrNotRecorded, req.Method, req.URL.RequestURI())
+		}
+		return
+	}
+
+	var exchange Exchange
+	err = json.Unmarshal(bs, &exchange)
+	if err != nil {
+		return
+	}
+
+	resp = &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.StatusCode, http.StatusText(exchange.StatusCode)),
+		StatusCode:    exchange.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        exchange.Header,
+		Body:          io.NopCloser(strings.NewReader(exchange.ResponseBody)),
+		ContentLength: int64(len(exchange.ResponseBody)),
+		Request:       req,
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+
+	return
+}
+
+func NewReplayFetcher(dir string) (replayFetcher *ReplayFetcher, err error) {
+	s, err := os.Stat(dir)
+	if err != nil {
+		return
+	}
+	if !s.IsDir() {
+		err = fmt.Errorf("%s is not a dir", dir)
+		return
+	}
+
+	replayFetcher = &ReplayFetcher{
+		dir: dir,
+	}
+	return
+}
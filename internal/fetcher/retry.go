@@ -0,0 +1,197 @@
+package fetcher
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrConsent the request was redirected to the cookie consent page
+var ErrConsent = errors.New("redirected to consent page")
+
+// StatusError a response with a status the spider can not use
+type StatusError struct {
+	StatusCode int
+	Url        string
+	Retryable  bool
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status %d %s", e.StatusCode, e.Url)
+}
+
+// Classify turn a response into an error, nil if it can be used.
+// A response without its request is classified by its status only
+func Classify(resp *http.Response) (err error) {
+	u := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		requestUrl := resp.Request.URL
+		u = requestUrl.String()
+		if strings.HasPrefix(requestUrl.Host, "consent.") {
+			err = fmt.Errorf("%w: %s", ErrConsent, u)
+			return
+		}
+
+		// the "unusual traffic" page
+		if strings.HasPrefix(requestUrl.Path, "/sorry/") {
+			err = &StatusError{
+				StatusCode: http.StatusTooManyRequests,
+				Url:        u,
+				Retryable:  true,
+			}
+			return
+		}
+	}
+
+	code := resp.StatusCode
+	if code >= 200 && code < 300 {
+		return
+	}
+
+	err = &StatusError{
+		StatusCode: code,
+		Url:        u,
+		Retryable: code == http.StatusRequestTimeout ||
+			code == http.StatusTooManyRequests ||
+			code == http.StatusInternalServerError ||
+			code == http.StatusBadGateway ||
+			code == http.StatusServiceUnavailable ||
+			code == http.StatusGatewayTimeout,
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After")),
+	}
+	return
+}
+
+// retryAfter parse a Retry-After header, in seconds or as a date
+func retryAfter(v string) (d time.Duration) {
+	if v == "" {
+		return
+	}
+
+	seconds, err := strconv.Atoi(v)
+	if err == nil {
+		d = time.Duration(seconds) * time.Second
+		return
+	}
+
+	t, err := http.ParseTime(v)
+	if err == nil {
+		d = time.Until(t)
+	}
+	if d < 0 {
+		d = 0
+	}
+	return
+}
+
+// RetryFetcher classify responses and retry the retryable ones with exponential backoff
+type RetryFetcher struct {
+	fetcher     Fetcher
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// backoff the delay before the next attempt, with full jitter over its upper half
+func (f *RetryFetcher) backoff(attempt int) time.Duration {
+	d := f.baseDelay << attempt
+	if d <= 0 || d > f.maxDelay {
+		d = f.maxDelay
+	}
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func (f *RetryFetcher) Do(req *http.Request) (resp *http.Response, err error) {
+	ctx := req.Context()
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return
+	}
+
+	for attempt := 0; attempt < f.maxAttempts; attempt++ {
+		if attempt > 0 && reqBody != nil {
+			req.Body = io.NopCloser(bytes.NewReader(reqBody))
+		}
+
+		resp, err = f.fetcher.Do(req)
+		if err == nil {
+			err = Classify(resp)
+			if err == nil {
+				return
+			}
+			_ = resp.Body.Close()
+			resp = nil
+		}
+
+		if ctx.Err() != nil {
+			err = ctx.Err()
+			return
+		}
+
+		delay := f.backoff(attempt)
+		var statusError *StatusError
+		if errors.As(err, &statusError) {
+			if !statusError.Retryable {
+				return
+			}
+			// waiting longer than maxDelay is up to the caller, no retry would come in time
+			if statusError.RetryAfter > f.maxDelay {
+				return
+			}
+			if statusError.RetryAfter > delay {
+				delay = statusError.RetryAfter
+			}
+		} else if errors.Is(err, ErrConsent) || errors.Is(err, ErrNotRecorded) {
+			return
+		}
+
+		if attempt+1 >= f.maxAttempts {
+			break
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			err = ctx.Err()
+			return
+		}
+	}
+
+	err = fmt.Errorf("after %d attempts: %w", f.maxAttempts, err)
+	return
+}
+
+// NewRetryFetcher try a request up to maxAttempts times,
+// waiting baseDelay, doubled on every attempt up to maxDelay, in between.
+// A Retry-After longer than maxDelay ends the retries with its error
+func NewRetryFetcher(fetcher Fetcher, maxAttempts int, baseDelay time.Duration, maxDelay time.Duration) (retryFetcher *RetryFetcher) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	if baseDelay <= 0 {
+		baseDelay = time.Second
+	}
+	if maxDelay < baseDelay {
+		maxDelay = baseDelay
+	}
+
+	retryFetcher = &RetryFetcher{
+		fetcher:     fetcher,
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		maxDelay:    maxDelay,
+	}
+	return
+}
@@ -0,0 +1,53 @@
+package fetcher
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	for _, v := range []struct {
+		name      string
+		url       string
+		status    int
+		err       bool
+		retryable bool
+	}{
+		{"ok", "https://www.youtube.com/@alice/videos", http.StatusOK, false, false},
+		{"not found", "https://www.youtube.com/@nobody/videos", http.StatusNotFound, true, false},
+		{"unavailable", "https://www.youtube.com/@alice/videos", http.StatusServiceUnavailable, true, true},
+		{"unusual traffic", "https://www.google.com/sorry/index", http.StatusOK, true, true},
+		{"ok without a request", "", http.StatusOK, false, false},
+		{"too many requests without a request", "", http.StatusTooManyRequests, true, true},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: v.status, Header: http.Header{}}
+			if v.url != "" {
+				u, err := url.Parse(v.url)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Request = &http.Request{URL: u}
+			}
+
+			err := Classify(resp)
+			if (err != nil) != v.err {
+				t.Fatalf("%v, want an error %t", err, v.err)
+			}
+			var statusError *StatusError
+			if errors.As(err, &statusError) && statusError.Retryable != v.retryable {
+				t.Errorf("retryable %t, want %t", statusError.Retryable, v.retryable)
+			}
+		})
+	}
+}
+
+func TestClassifyConsent(t *testing.T) {
+	u, _ := url.Parse("https://consent.youtube.com/m")
+	err := Classify(&http.Response{StatusCode: http.StatusOK, Request: &http.Request{URL: u}})
+	if !errors.Is(err, ErrConsent) {
+		t.Errorf("%v, want ErrConsent", err)
+	}
+}
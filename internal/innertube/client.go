@@ -0,0 +1,244 @@
+package innertube
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/fetcher"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const userAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"
+
+type Endpoint string
+
+const (
+	EndpointBrowse Endpoint = "browse"
+	EndpointSearch Endpoint = "search"
+	EndpointNext   Endpoint = "next"
+	EndpointPlayer Endpoint = "player"
+)
+
+// Error an error object in a response
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("innertube %d %s: %s", e.Code, e.Status, e.Message)
+}
+
+// Response the body of an innertube response
+type Response struct {
+	Endpoint Endpoint
+	Body     []byte
+}
+
+// Decode unmarshal the body into v
+func (r *Response) Decode(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Continuations the continuation tokens of the response, in document order
+func (r *Response) Continuations() (continuations []Continuation) {
+	continuations, _ = findContinuations(r.Endpoint, r.Body)
+	return
+}
+
+// Client the innertube api of the web client.
+// The client context is discovered from ytcfg, of the pages passed to Observe
+// or of the home page once the cached one has expired.
+type Client struct {
+	fetcher    fetcher.Fetcher
+	baseUrl    string
+	apiBaseUrl string
+	cache      *Cache
+}
+
+// Observe cache the ytcfg of a page fetched by the caller
+func (c *Client) Observe(body []byte) (err error) {
+	ytcfg, err := ParseYtcfg(body)
+	if err != nil {
+		return
+	}
+	c.cache.Set(ytcfg)
+	return
+}
+
+// Ytcfg the cached ytcfg, or the one of the home page if it has expired
+func (c *Client) Ytcfg(ctx context.Context) (ytcfg Ytcfg, err error) {
+	ytcfg, ok := c.cache.Get()
+	if ok {
+		return
+	}
+
+	body, err := c.Get(ctx, c.baseUrl+"/")
+	if err != nil {
+		return
+	}
+
+	ytcfg, err = ParseYtcfg(body)
+	if err != nil {
+		return
+	}
+	c.cache.Set(ytcfg)
+
+	// hl and gl as overridden by the cache
+	ytcfg, _ = c.cache.Get()
+	return
+}
+
+// Hl the language pages are in, empty if it is neither configured nor discovered yet
+func (c *Client) Hl() string {
+	return c.cache.Hl()
+}
+
+// Get fetch a web page, e.g. to read its ytInitialData.
+// The page is asked for in the language and country of the innertube context, unless u sets them
+func (c *Client) Get(ctx context.Context, u string) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return
+	}
+
+	query := req.URL.Query()
+	localized := false
+	if hl := c.cache.Hl(); hl != "" && query.Get("hl") == "" {
+		query.Set("hl", hl)
+		localized = true
+	}
+	if gl := c.cache.Gl(); gl != "" && query.Get("gl") == "" {
+		query.Set("gl", gl)
+		localized = true
+	}
+	if localized {
+		req.URL.RawQuery = query.Encode()
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	body, err = c.do(req)
+	return
+}
+
+func (c *Client) do(req *http.Request) (body []byte, err error) {
+	resp, err := c.fetcher.Do(req)
+	if err != nil {
+		return
+	}
+
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	body, err = io.ReadAll(resp.Body)
+	return
+}
+
+func (c *Client) post(ctx context.Context, endpoint Endpoint, request Request) (resp *Response, err error) {
+	ytcfg, err := c.Ytcfg(ctx)
+	if err != nil {
+		return
+	}
+
+	request.Context = ytcfg.Context
+	bs, err := json.Marshal(request)
+	if err != nil {
+		return
+	}
+
+	u := fmt.Sprintf("%s/youtubei/v1/%s?key=%s&prettyPrint=false", c.apiBaseUrl, endpoint, url.QueryEscape(ytcfg.ApiKey))
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(bs))
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	ytcfg.SetHeaders(req.Header)
+
+	body, err := c.do(req)
+	if err != nil {
+		return
+	}
+
+	var errorBody struct {
+		Error *Error `json:"error"`
+	}
+	err = json.Unmarshal(body, &errorBody)
+	if err != nil {
+		return
+	}
+	if errorBody.Error != nil {
+		err = errorBody.Error
+		return
+	}
+
+	resp = &Response{
+		Endpoint: endpoint,
+		Body:     body,
+	}
+	return
+}
+
+// Browse a channel, playlist or tab of them, params selecting the tab
+func (c *Client) Browse(ctx context.Context, browseId string, params string) (resp *Response, err error) {
+	resp, err = c.post(ctx, EndpointBrowse, Request{
+		BrowseId: browseId,
+		Params:   params,
+	})
+	return
+}
+
+// Search query, params holding the encoded filters
+func (c *Client) Search(ctx context.Context, query string, params string) (resp *Response, err error) {
+	resp, err = c.post(ctx, EndpointSearch, Request{
+		Query:  query,
+		Params: params,
+	})
+	return
+}
+
+// Next the watch page data of a video: details, related videos and the comments entry point
+func (c *Client) Next(ctx context.Context, videoId string) (resp *Response, err error) {
+	resp, err = c.post(ctx, EndpointNext, Request{
+		VideoId: videoId,
+	})
+	return
+}
+
+// Player the player data of a video: details, microformat and playability
+func (c *Client) Player(ctx context.Context, videoId string) (resp *Response, err error) {
+	resp, err = c.post(ctx, EndpointPlayer, Request{
+		VideoId: videoId,
+	})
+	return
+}
+
+// Continue fetch the next part of an earlier response
+func (c *Client) Continue(ctx context.Context, continuation Continuation) (resp *Response, err error) {
+	if continuation.Token == "" {
+		err = fmt.Errorf("empty continuation token for %s", continuation.Endpoint)
+		return
+	}
+
+	resp, err = c.post(ctx, continuation.Endpoint, Request{
+		Continuation: continuation.Token,
+	})
+	return
+}
+
+func NewClient(fetcher fetcher.Fetcher, baseUrl string, apiBaseUrl string, cache *Cache) (client *Client) {
+	client = &Client{
+		fetcher:    fetcher,
+		baseUrl:    strings.TrimSuffix(baseUrl, "/"),
+		apiBaseUrl: strings.TrimSuffix(apiBaseUrl, "/"),
+		cache:      cache,
+	}
+	return
+}
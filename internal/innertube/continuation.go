@@ -0,0 +1,81 @@
+package innertube
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Continuation a token to fetch the next part of a response from endpoint
+type Continuation struct {
+	Endpoint Endpoint
+	Token    string
+	// e.g. CONTINUATION_REQUEST_TYPE_SEARCH, empty for browse and next
+	Request string
+}
+
+type continuationCommand struct {
+	Token   string `json:"token"`
+	Request string `json:"request"`
+}
+
+// findContinuations collect every continuationCommand of body, in document order
+func findContinuations(endpoint Endpoint, body []byte) (continuations []Continuation, err error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	err = walk(dec, func(cmd continuationCommand) {
+		if cmd.Token == "" {
+			return
+		}
+		continuations = append(continuations, Continuation{
+			Endpoint: endpoint,
+			Token:    cmd.Token,
+			Request:  cmd.Request,
+		})
+	})
+	return
+}
+
+func walk(dec *json.Decoder, found func(cmd continuationCommand)) (err error) {
+	t, err := dec.Token()
+	if err != nil {
+		return
+	}
+
+	d, ok := t.(json.Delim)
+	if !ok {
+		return
+	}
+
+	switch d {
+	case '{':
+		for dec.More() {
+			t, err = dec.Token()
+			if err != nil {
+				return
+			}
+			if t == "continuationCommand" {
+				var cmd continuationCommand
+				err = dec.Decode(&cmd)
+				if err != nil {
+					return
+				}
+				found(cmd)
+				continue
+			}
+			err = walk(dec, found)
+			if err != nil {
+				return
+			}
+		}
+	case '[':
+		for dec.More() {
+			err = walk(dec, found)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// the closing delim
+	_, err = dec.Token()
+	return
+}
@@ -0,0 +1,168 @@
+package innertube
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+type UploadDate string
+
+const (
+	UploadHour  UploadDate = "hour"
+	UploadToday UploadDate = "today"
+	UploadWeek  UploadDate = "week"
+	UploadMonth UploadDate = "month"
+	UploadYear  UploadDate = "year"
+)
+
+type ResultType string
+
+const (
+	TypeVideo    ResultType = "video"
+	TypeChannel  ResultType = "channel"
+	TypePlaylist ResultType = "playlist"
+)
+
+type VideoDuration string
+
+const (
+	// DurationShort under 4 minutes
+	DurationShort VideoDuration = "short"
+	// DurationMedium 4 to 20 minutes
+	DurationMedium VideoDuration = "medium"
+	// DurationLong over 20 minutes
+	DurationLong VideoDuration = "long"
+)
+
+type SortOrder string
+
+const (
+	SortRelevance SortOrder = "relevance"
+	SortRating    SortOrder = "rating"
+	SortDate      SortOrder = "date"
+	SortViews     SortOrder = "views"
+)
+
+type Feature string
+
+const (
+	FeatureLive            Feature = "live"
+	Feature4K              Feature = "4k"
+	FeatureCC              Feature = "cc"
+	FeatureCreativeCommons Feature = "creative_commons"
+)
+
+// the values are the field numbers and enum values of the protobuf behind sp
+var (
+	uploadDates = map[UploadDate]uint64{
+		UploadHour:  1,
+		UploadToday: 2,
+		UploadWeek:  3,
+		UploadMonth: 4,
+		UploadYear:  5,
+	}
+	resultTypes = map[ResultType]uint64{
+		TypeVideo:    1,
+		TypeChannel:  2,
+		TypePlaylist: 3,
+	}
+	durations = map[VideoDuration]uint64{
+		DurationShort:  1,
+		DurationLong:   2,
+		DurationMedium: 3,
+	}
+	sortOrders = map[SortOrder]uint64{
+		SortRelevance: 0,
+		SortRating:    1,
+		SortDate:      2,
+		SortViews:     3,
+	}
+	features = map[Feature]uint64{
+		FeatureCC:              5,
+		FeatureCreativeCommons: 6,
+		FeatureLive:            8,
+		Feature4K:              14,
+	}
+)
+
+// SearchFilter the filters of the search page. Empty fields do not filter
+type SearchFilter struct {
+	UploadDate UploadDate    `yaml:"upload_date" json:"upload_date,omitempty"`
+	Type       ResultType    `yaml:"type" json:"type,omitempty"`
+	Duration   VideoDuration `yaml:"duration" json:"duration,omitempty"`
+	Sort       SortOrder     `yaml:"sort" json:"sort,omitempty"`
+	Features   []Feature     `yaml:"features" json:"features,omitempty"`
+}
+
+// Params the filter encoded like the sp parameter of /results, which is also the params of search.
+// It is empty if nothing is filtered
+func (f *SearchFilter) Params() (params string, err error) {
+	var filters []byte
+	if f.UploadDate != "" {
+		v, ok := uploadDates[f.UploadDate]
+		if !ok {
+			err = fmt.Errorf("unknown upload date %q", f.UploadDate)
+			return
+		}
+		filters = appendVarintField(filters, 1, v)
+	}
+	if f.Type != "" {
+		v, ok := resultTypes[f.Type]
+		if !ok {
+			err = fmt.Errorf("unknown result type %q", f.Type)
+			return
+		}
+		filters = appendVarintField(filters, 2, v)
+	}
+	if f.Duration != "" {
+		v, ok := durations[f.Duration]
+		if !ok {
+			err = fmt.Errorf("unknown duration %q", f.Duration)
+			return
+		}
+		filters = appendVarintField(filters, 3, v)
+	}
+	for _, feature := range f.Features {
+		field, ok := features[feature]
+		if !ok {
+			err = fmt.Errorf("unknown feature %q", feature)
+			return
+		}
+		filters = appendVarintField(filters, field, 1)
+	}
+
+	var bs []byte
+	if f.Sort != "" {
+		v, ok := sortOrders[f.Sort]
+		if !ok {
+			err = fmt.Errorf("unknown sort order %q", f.Sort)
+			return
+		}
+		if v > 0 {
+			bs = appendVarintField(bs, 1, v)
+		}
+	}
+	if len(filters) > 0 {
+		bs = appendVarint(bs, 2<<3|2)
+		bs = appendVarint(bs, uint64(len(filters)))
+		bs = append(bs, filters...)
+	}
+	if len(bs) == 0 {
+		return
+	}
+
+	params = base64.StdEncoding.EncodeToString(bs)
+	return
+}
+
+func appendVarintField(bs []byte, field uint64, v uint64) []byte {
+	return appendVarint(appendVarint(bs, field<<3), v)
+}
+
+func appendVarint(bs []byte, v uint64) []byte {
+	for v >= 0x80 {
+		bs = append(bs, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(bs, byte(v))
+}
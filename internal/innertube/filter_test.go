@@ -0,0 +1,41 @@
+package innertube
+
+import "testing"
+
+func TestSearchFilterParams(t *testing.T) {
+	for _, v := range []struct {
+		filter SearchFilter
+		params string
+	}{
+		{SearchFilter{}, ""},
+		{SearchFilter{Sort: SortRelevance}, ""},
+		{SearchFilter{Type: TypeVideo}, "EgIQAQ=="},
+		{SearchFilter{Sort: SortDate}, "CAI="},
+		{SearchFilter{UploadDate: UploadHour}, "EgIIAQ=="},
+		{SearchFilter{Features: []Feature{Feature4K}}, "EgJwAQ=="},
+		{SearchFilter{Sort: SortViews, Type: TypeChannel}, "CAMSAhAC"},
+	} {
+		params, err := v.filter.Params()
+		if err != nil {
+			t.Errorf("%+v: %v", v.filter, err)
+			continue
+		}
+		if params != v.params {
+			t.Errorf("%+v: %q, want %q", v.filter, params, v.params)
+		}
+	}
+}
+
+func TestSearchFilterParamsUnknown(t *testing.T) {
+	for _, v := range []SearchFilter{
+		{UploadDate: "decade"},
+		{Type: "movie"},
+		{Duration: "endless"},
+		{Sort: "random"},
+		{Features: []Feature{"3d"}},
+	} {
+		if params, err := v.Params(); err == nil {
+			t.Errorf("%+v: %q, want an error", v, params)
+		}
+	}
+}
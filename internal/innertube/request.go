@@ -0,0 +1,11 @@
+package innertube
+
+// Request the body of an innertube request
+type Request struct {
+	Context      Context `json:"context"`
+	BrowseId     string  `json:"browseId,omitempty"`
+	Params       string  `json:"params,omitempty"`
+	Query        string  `json:"query,omitempty"`
+	VideoId      string  `json:"videoId,omitempty"`
+	Continuation string  `json:"continuation,omitempty"`
+}
@@ -0,0 +1,159 @@
+package innertube
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrNoYtcfg the page has no ytcfg with an api key
+var ErrNoYtcfg = errors.New("not find ytcfg")
+
+// ClientInfo context.client of every innertube request
+type ClientInfo struct {
+	Hl            string `json:"hl"`
+	Gl            string `json:"gl"`
+	ClientName    string `json:"clientName"`
+	ClientVersion string `json:"clientVersion"`
+	VisitorData   string `json:"visitorData,omitempty"`
+	TimeZone      string `json:"timeZone,omitempty"`
+	UtcOffset     int    `json:"utcOffsetMinutes,omitempty"`
+}
+
+// Context the context of every innertube request
+type Context struct {
+	Client ClientInfo `json:"client"`
+}
+
+// Ytcfg what the web client is configured with, from ytcfg.set in the html
+type Ytcfg struct {
+	ApiKey      string  `json:"INNERTUBE_API_KEY"`
+	Context     Context `json:"INNERTUBE_CONTEXT"`
+	ClientName  int     `json:"INNERTUBE_CONTEXT_CLIENT_NAME"`
+	VisitorData string  `json:"VISITOR_DATA"`
+}
+
+// SetHeaders set the headers the web client sends along with innertube requests
+func (y *Ytcfg) SetHeaders(header http.Header) {
+	header.Set("Content-Type", "application/json")
+	if y.ClientName > 0 {
+		header.Set("X-Youtube-Client-Name", strconv.Itoa(y.ClientName))
+	}
+	header.Set("X-Youtube-Client-Version", y.Context.Client.ClientVersion)
+	if y.Context.Client.VisitorData != "" {
+		header.Set("X-Goog-Visitor-Id", y.Context.Client.VisitorData)
+	}
+}
+
+var ytcfgSet = []byte("ytcfg.set({")
+
+// ParseYtcfg merge every ytcfg.set({...}) call of the page
+func ParseYtcfg(body []byte) (ytcfg Ytcfg, err error) {
+	for {
+		i := bytes.Index(body, ytcfgSet)
+		if i < 0 {
+			break
+		}
+		body = body[i+len(ytcfgSet)-1:]
+
+		// the decoder stops at the end of the object, whatever follows.
+		// a call that does not decode is skipped
+		_ = json.NewDecoder(bytes.NewReader(body)).Decode(&ytcfg)
+		body = body[1:]
+	}
+
+	if ytcfg.ApiKey == "" || ytcfg.Context.Client.ClientVersion == "" {
+		err = ErrNoYtcfg
+		return
+	}
+
+	if ytcfg.Context.Client.VisitorData == "" {
+		ytcfg.Context.Client.VisitorData = ytcfg.VisitorData
+	}
+
+	return
+}
+
+// Cache the latest ytcfg, until ttl is over
+type Cache struct {
+	ttl time.Duration
+	hl  string
+	gl  string
+
+	mu      sync.RWMutex
+	ytcfg   Ytcfg
+	expires time.Time
+}
+
+// Get the cached ytcfg, false if there is none or it has expired
+func (c *Cache) Get() (ytcfg Ytcfg, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if time.Now().After(c.expires) {
+		return
+	}
+
+	ytcfg = c.ytcfg
+	ok = true
+	return
+}
+
+// Hl the language of the pages: the one configured, or else the one last discovered, expired or not
+func (c *Cache) Hl() string {
+	if c.hl != "" {
+		return c.hl
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.ytcfg.Context.Client.Hl
+}
+
+// Gl the country of the pages: the one configured, or else the one last discovered, expired or not
+func (c *Cache) Gl() string {
+	if c.gl != "" {
+		return c.gl
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.ytcfg.Context.Client.Gl
+}
+
+// Set cache ytcfg, with hl and gl overridden if configured
+func (c *Cache) Set(ytcfg Ytcfg) {
+	if c.hl != "" {
+		ytcfg.Context.Client.Hl = c.hl
+	}
+	if c.gl != "" {
+		ytcfg.Context.Client.Gl = c.gl
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ytcfg = ytcfg
+	c.expires = time.Now().Add(c.ttl)
+}
+
+// NewCache keep a ytcfg for ttl.
+// hl and gl, if not empty, replace the discovered language and country.
+func NewCache(ttl time.Duration, hl string, gl string) (cache *Cache) {
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+
+	cache = &Cache{
+		ttl: ttl,
+		hl:  hl,
+		gl:  gl,
+	}
+	return
+}
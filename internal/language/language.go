@@ -0,0 +1,38 @@
+package language
+
+import (
+	"sort"
+	"strings"
+)
+
+// Langs the languages to read the texts of a page in hl with, its base language first, e.g. pt of pt-BR.
+// English is tried too, for pages that are not in the language asked for,
+// or when hl is empty or a language without words known
+func Langs(hl string) (langs []string) {
+	l, _, _ := strings.Cut(strings.ToLower(hl), "-")
+	// Norwegian is Bokmål
+	if l == "no" {
+		l = "nb"
+	}
+	if l != "" && l != "en" {
+		langs = append(langs, l)
+	}
+	langs = append(langs, "en")
+	return
+}
+
+// SortedWords the words of each language, longest first so that a word wins over its prefixes
+func SortedWords[V any](words map[string]map[string]V) (sorted map[string][]string) {
+	sorted = make(map[string][]string, len(words))
+	for k, v := range words {
+		var keys []string
+		for k1 := range v {
+			keys = append(keys, k1)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return len(keys[i]) > len(keys[j])
+		})
+		sorted[k] = keys
+	}
+	return
+}
@@ -0,0 +1,32 @@
+package language
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLangs(t *testing.T) {
+	for _, v := range []struct {
+		hl    string
+		langs []string
+	}{
+		{"", []string{"en"}},
+		{"en", []string{"en"}},
+		{"en-GB", []string{"en"}},
+		{"pt-BR", []string{"pt", "en"}},
+		{"zh-TW", []string{"zh", "en"}},
+		{"no", []string{"nb", "en"}},
+		{"DE", []string{"de", "en"}},
+	} {
+		if langs := Langs(v.hl); !reflect.DeepEqual(langs, v.langs) {
+			t.Errorf("%q: %v, want %v", v.hl, langs, v.langs)
+		}
+	}
+}
+
+func TestSortedWords(t *testing.T) {
+	sorted := SortedWords(map[string]map[string]int{"nl": {"minuut": 1, "minuten": 1, "uur": 2}})
+	if words := sorted["nl"]; !reflect.DeepEqual(words, []string{"minuten", "minuut", "uur"}) {
+		t.Errorf("%v, want [minuten minuut uur]", words)
+	}
+}
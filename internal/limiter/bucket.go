@@ -0,0 +1,95 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Bucket a token bucket, refilled at rate tokens per second up to burst
+type Bucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// reserve take a token, return how long to wait before using it
+func (b *Bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel give back a reserved token that was not used
+func (b *Bucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens++
+}
+
+// Wait block until a token is available or ctx is done
+func (b *Bucket) Wait(ctx context.Context) (err error) {
+	if b == nil {
+		return
+	}
+
+	d := b.reserve(time.Now())
+	err = sleep(ctx, d)
+	if err != nil {
+		b.cancel()
+		return
+	}
+
+	return
+}
+
+func sleep(ctx context.Context, d time.Duration) (err error) {
+	if d <= 0 {
+		err = ctx.Err()
+		return
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	return
+}
+
+// NewBucket allow rpm requests per minute, with bursts of up to burst.
+// A rpm of zero or less means no limit, and nil is returned.
+func NewBucket(rpm float64, burst int) (bucket *Bucket) {
+	if rpm <= 0 {
+		return
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	bucket = &Bucket{
+		rate:   rpm / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+	return
+}
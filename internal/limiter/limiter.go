@@ -0,0 +1,106 @@
+package limiter
+
+import (
+	"github.com/lizongying/go-youtube/internal/config"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Endpoint string
+
+const (
+	EndpointSearch  Endpoint = "search"
+	EndpointBrowse  Endpoint = "browse"
+	EndpointChannel Endpoint = "channel"
+	EndpointOther   Endpoint = "other"
+)
+
+// EndpointOf classify a request by the youtube page or api it hits
+func EndpointOf(req *http.Request) Endpoint {
+	p := req.URL.Path
+	switch {
+	case p == "/results" || p == "/youtubei/v1/search":
+		return EndpointSearch
+	case p == "/youtubei/v1/browse":
+		return EndpointBrowse
+	case strings.HasPrefix(p, "/@") || strings.HasPrefix(p, "/channel/"):
+		return EndpointChannel
+	default:
+		return EndpointOther
+	}
+}
+
+type rule struct {
+	rpm   float64
+	burst int
+}
+
+// Limiter throttle requests per host and endpoint, under a global ceiling
+type Limiter struct {
+	global *Bucket
+	rules  map[Endpoint]rule
+	jitter time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*Bucket
+}
+
+func (l *Limiter) bucket(host string, endpoint Endpoint) *Bucket {
+	r, ok := l.rules[endpoint]
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	k := host + " " + string(endpoint)
+	b, ok := l.buckets[k]
+	if !ok {
+		b = NewBucket(r.rpm, r.burst)
+		l.buckets[k] = b
+	}
+	return b
+}
+
+// Wait block until req may be sent, or its context is done
+func (l *Limiter) Wait(req *http.Request) (err error) {
+	ctx := req.Context()
+
+	err = l.global.Wait(ctx)
+	if err != nil {
+		return
+	}
+
+	err = l.bucket(req.URL.Host, EndpointOf(req)).Wait(ctx)
+	if err != nil {
+		return
+	}
+
+	if l.jitter > 0 {
+		err = sleep(ctx, time.Duration(rand.Int63n(int64(l.jitter))))
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func NewLimiter(config *config.Config) (limiter *Limiter) {
+	c := config.Spider.Limit
+	limiter = &Limiter{
+		global: NewBucket(c.Rpm, c.Burst),
+		rules: map[Endpoint]rule{
+			EndpointSearch:  {c.Search.Rpm, c.Search.Burst},
+			EndpointBrowse:  {c.Browse.Rpm, c.Browse.Burst},
+			EndpointChannel: {c.Channel.Rpm, c.Channel.Burst},
+		},
+		jitter:  c.Jitter,
+		buckets: make(map[string]*Bucket),
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrClosed the pool does not accept tasks after Close
+var ErrClosed = errors.New("pool closed")
+
+// Task a unit of work run by one of the workers
+type Task func(ctx context.Context) error
+
+// WorkerError a failed task, with the worker that ran it
+type WorkerError struct {
+	Worker int
+	Name   string
+	Err    error
+}
+
+func (e *WorkerError) Error() string {
+	return fmt.Sprintf("worker %d %s: %s", e.Worker, e.Name, e.Err)
+}
+
+func (e *WorkerError) Unwrap() error {
+	return e.Err
+}
+
+type task struct {
+	name string
+	fn   Task
+}
+
+// Pool run tasks on a fixed number of workers.
+// At most maxInFlight tasks are queued or running, Submit blocks beyond that.
+type Pool struct {
+	ctx      context.Context
+	tasks    chan task
+	inFlight chan struct{}
+	onError  func(err *WorkerError)
+
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	closed bool
+	errs   []error
+}
+
+func (p *Pool) work(worker int) {
+	defer p.wg.Done()
+
+	for t := range p.tasks {
+		err := t.fn(p.ctx)
+		<-p.inFlight
+		if err == nil {
+			continue
+		}
+
+		workerError := &WorkerError{
+			Worker: worker,
+			Name:   t.name,
+			Err:    err,
+		}
+		if p.onError != nil {
+			p.onError(workerError)
+		}
+		p.mu.Lock()
+		p.errs = append(p.errs, workerError)
+		p.mu.Unlock()
+	}
+}
+
+// Submit queue a task, waiting while the pool is full
+func (p *Pool) Submit(ctx context.Context, name string, fn Task) (err error) {
+	select {
+	case p.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		err = ctx.Err()
+		return
+	case <-p.ctx.Done():
+		err = p.ctx.Err()
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		<-p.inFlight
+		err = ErrClosed
+		return
+	}
+
+	// never blocks, the channel has room for every in-flight task
+	p.tasks <- task{
+		name: name,
+		fn:   fn,
+	}
+	return
+}
+
+// Close stop accepting tasks, wait for the queued and running ones to finish
+// and return the errors of the failed ones
+func (p *Pool) Close() (err error) {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.tasks)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	err = errors.Join(p.errs...)
+	return
+}
+
+// NewPool start workers running tasks with ctx.
+// onError, if not nil, is called from the worker as soon as a task fails.
+func NewPool(ctx context.Context, workers int, maxInFlight int, onError func(err *WorkerError)) (pool *Pool) {
+	if workers < 1 {
+		workers = 1
+	}
+	if maxInFlight < workers {
+		maxInFlight = workers
+	}
+
+	pool = &Pool{
+		ctx:      ctx,
+		tasks:    make(chan task, maxInFlight),
+		inFlight: make(chan struct{}, maxInFlight),
+		onError:  onError,
+	}
+
+	pool.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go pool.work(i)
+	}
+
+	return
+}
@@ -0,0 +1,271 @@
+package proxyPool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/config"
+	"github.com/lizongying/go-youtube/internal/fetcher"
+	"github.com/lizongying/go-youtube/internal/logger"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrNoProxy every proxy is quarantined
+var ErrNoProxy = errors.New("no healthy proxy")
+
+type Rotation string
+
+const (
+	RotationRoundRobin Rotation = "round_robin"
+	RotationRandom     Rotation = "random"
+	RotationSticky     Rotation = "sticky"
+)
+
+type ctxKey int
+
+const (
+	ctxKeySticky ctxKey = iota
+	ctxKeyProxy
+)
+
+// WithKey requests made with the returned context share a proxy under sticky rotation,
+// e.g. all requests for one channel
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, ctxKeySticky, key)
+}
+
+type proxy struct {
+	url              *url.URL
+	failures         int
+	quarantinedUntil time.Time
+	checking         bool
+}
+
+// ProxyPool rotate requests over proxies and quarantine the failing ones.
+// Quarantined proxies are checked again once their quarantine is over.
+type ProxyPool struct {
+	logger      *logger.Logger
+	rotation    Rotation
+	maxFailures int
+	quarantine  time.Duration
+	checkUrl    string
+
+	mu      sync.Mutex
+	proxies []*proxy
+	next    int
+}
+
+func (p *ProxyPool) healthy(pr *proxy, now time.Time) bool {
+	if pr.quarantinedUntil.IsZero() {
+		return true
+	}
+	if now.After(pr.quarantinedUntil) && !pr.checking {
+		pr.checking = true
+		go p.check(pr)
+	}
+	return false
+}
+
+func (p *ProxyPool) pick(ctx context.Context) (pr *proxy, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	n := len(p.proxies)
+	start := 0
+	switch p.rotation {
+	case RotationRandom:
+		start = rand.Intn(n)
+	case RotationSticky:
+		key, _ := ctx.Value(ctxKeySticky).(string)
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(key))
+		start = int(h.Sum32() % uint32(n))
+	default:
+		start = p.next
+		p.next = (p.next + 1) % n
+	}
+
+	// fall through to the next healthy one, so a sticky key moves
+	// only while its proxy is quarantined
+	for i := 0; i < n; i++ {
+		candidate := p.proxies[(start+i)%n]
+		if p.healthy(candidate, now) {
+			pr = candidate
+			return
+		}
+	}
+
+	err = ErrNoProxy
+	return
+}
+
+// Route attach a proxy to the request, nil if there are no proxies configured
+func (p *ProxyPool) Route(req *http.Request) (routed *http.Request, err error) {
+	if len(p.proxies) == 0 {
+		routed = req
+		return
+	}
+
+	pr, err := p.pick(req.Context())
+	if err != nil {
+		return
+	}
+
+	routed = req.WithContext(context.WithValue(req.Context(), ctxKeyProxy, pr))
+	return
+}
+
+// Report learn from the outcome of a routed request
+func (p *ProxyPool) Report(req *http.Request, err error) {
+	pr, ok := req.Context().Value(ctxKeyProxy).(*proxy)
+	if !ok {
+		return
+	}
+
+	if err == nil {
+		p.mu.Lock()
+		pr.failures = 0
+		p.mu.Unlock()
+		return
+	}
+	if req.Context().Err() != nil || !blocked(err) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pr.failures++
+	if pr.failures >= p.maxFailures && pr.quarantinedUntil.IsZero() {
+		pr.quarantinedUntil = time.Now().Add(p.quarantine)
+		p.logger.Warning("proxy quarantined", pr.url.Redacted(), err)
+	}
+}
+
+// blocked whether the error is the proxy's fault rather than youtube's
+func blocked(err error) bool {
+	var statusError *fetcher.StatusError
+	if errors.As(err, &statusError) {
+		return statusError.StatusCode == http.StatusForbidden ||
+			statusError.StatusCode == http.StatusTooManyRequests ||
+			statusError.StatusCode == http.StatusProxyAuthRequired
+	}
+	return !errors.Is(err, fetcher.ErrConsent)
+}
+
+// Proxy the proxy for the request, for http.Transport
+func (p *ProxyPool) Proxy(req *http.Request) (u *url.URL, err error) {
+	pr, ok := req.Context().Value(ctxKeyProxy).(*proxy)
+	if ok {
+		u = pr.url
+	}
+	return
+}
+
+func (p *ProxyPool) check(pr *proxy) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyURL(pr.url),
+		},
+		Timeout: time.Second * 10,
+	}
+
+	err := func() (err error) {
+		resp, err := client.Get(p.checkUrl)
+		if err != nil {
+			return
+		}
+		_ = resp.Body.Close()
+		err = fetcher.Classify(resp)
+		return
+	}()
+	client.CloseIdleConnections()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pr.checking = false
+	if err != nil {
+		pr.quarantinedUntil = time.Now().Add(p.quarantine)
+		p.logger.Warning("proxy still unhealthy", pr.url.Redacted(), err)
+		return
+	}
+
+	pr.failures = 0
+	pr.quarantinedUntil = time.Time{}
+	p.logger.Info("proxy back in rotation", pr.url.Redacted())
+}
+
+func NewProxyPool(config *config.Config, logger *logger.Logger) (proxyPool *ProxyPool, err error) {
+	c := config.Proxy
+	rotation := Rotation(c.Rotation)
+	switch rotation {
+	case "":
+		rotation = RotationRoundRobin
+	case RotationRoundRobin, RotationRandom, RotationSticky:
+	default:
+		err = fmt.Errorf("proxy rotation %s is not supported", c.Rotation)
+		logger.Error(err)
+		return
+	}
+
+	maxFailures := c.MaxFailures
+	if maxFailures < 1 {
+		maxFailures = 1
+	}
+	quarantine := c.Quarantine
+	if quarantine <= 0 {
+		quarantine = time.Minute * 5
+	}
+	checkUrl := c.CheckUrl
+	if checkUrl == "" {
+		checkUrl = "https://www.youtube.com/"
+	}
+
+	proxyPool = &ProxyPool{
+		logger:      logger,
+		rotation:    rotation,
+		maxFailures: maxFailures,
+		quarantine:  quarantine,
+		checkUrl:    checkUrl,
+	}
+
+	list := c.List
+	if c.Example != "" {
+		list = append([]string{c.Example}, list...)
+	}
+	for _, v := range list {
+		u, e := url.Parse(v)
+		if e != nil {
+			err = e
+			logger.Error(err)
+			return
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			err = fmt.Errorf("proxy scheme %s is not supported", u.Scheme)
+			logger.Error(err)
+			return
+		}
+		proxyPool.proxies = append(proxyPool.proxies, &proxy{
+			url: u,
+		})
+	}
+
+	if len(proxyPool.proxies) == 0 {
+		logger.Warning("no proxy, requests are sent directly")
+	}
+	// requests are not sent directly while every proxy is quarantined
+	if len(proxyPool.proxies) == 1 && maxFailures == 1 {
+		logger.Warning("a single proxy is quarantined at its first failure, requests fail for", quarantine)
+	}
+
+	return
+}
@@ -0,0 +1,221 @@
+package proxyPool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/config"
+	"github.com/lizongying/go-youtube/internal/fetcher"
+	"github.com/lizongying/go-youtube/internal/logger"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errBlocked = &fetcher.StatusError{StatusCode: http.StatusTooManyRequests}
+
+func newTestPool(t *testing.T, configure func(c *config.Config)) (proxyPool *ProxyPool) {
+	t.Helper()
+
+	c := &config.Config{}
+	c.Log.Level = "error"
+	if configure != nil {
+		configure(c)
+	}
+	l, err := logger.NewLogger(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxyPool, err = NewProxyPool(c, l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func withProxies(rotation Rotation, n int) func(c *config.Config) {
+	return func(c *config.Config) {
+		c.Proxy.Rotation = string(rotation)
+		for i := 0; i < n; i++ {
+			c.Proxy.List = append(c.Proxy.List, fmt.Sprintf("http://127.0.0.1:%d", 8001+i))
+		}
+	}
+}
+
+// route the proxy a request made with ctx goes through
+func route(t *testing.T, p *ProxyPool, ctx context.Context) (req *http.Request, proxy string) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://www.youtube.invalid/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err = p.Route(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := p.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u != nil {
+		proxy = u.Host
+	}
+	return
+}
+
+func TestDirect(t *testing.T) {
+	p := newTestPool(t, nil)
+
+	req, proxy := route(t, p, context.Background())
+	if proxy != "" {
+		t.Errorf("routed through %s, want directly", proxy)
+	}
+	// nothing to quarantine
+	p.Report(req, errBlocked)
+	if _, proxy = route(t, p, context.Background()); proxy != "" {
+		t.Errorf("routed through %s, want directly", proxy)
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	p := newTestPool(t, withProxies(RotationRoundRobin, 3))
+
+	var proxies []string
+	for i := 0; i < 6; i++ {
+		_, proxy := route(t, p, context.Background())
+		proxies = append(proxies, proxy)
+	}
+	want := []string{"127.0.0.1:8001", "127.0.0.1:8002", "127.0.0.1:8003", "127.0.0.1:8001", "127.0.0.1:8002", "127.0.0.1:8003"}
+	if fmt.Sprint(proxies) != fmt.Sprint(want) {
+		t.Errorf("%v, want %v", proxies, want)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	p := newTestPool(t, withProxies(RotationRandom, 3))
+
+	seen := map[string]int{}
+	for i := 0; i < 300; i++ {
+		_, proxy := route(t, p, context.Background())
+		seen[proxy]++
+	}
+	if len(seen) != 3 {
+		t.Errorf("%v, want all 3 proxies", seen)
+	}
+}
+
+func TestSticky(t *testing.T) {
+	p := newTestPool(t, withProxies(RotationSticky, 3))
+
+	seen := map[string]bool{}
+	for i := 0; i < 30; i++ {
+		ctx := WithKey(context.Background(), fmt.Sprintf("UC%d", i))
+		req, first := route(t, p, ctx)
+		for j := 0; j < 3; j++ {
+			if _, proxy := route(t, p, ctx); proxy != first {
+				t.Fatalf("key %d moved from %s to %s", i, first, proxy)
+			}
+		}
+		seen[first] = true
+
+		if i == 0 {
+			// a key moves while its proxy is quarantined only
+			p.Report(req, errBlocked)
+			if _, proxy := route(t, p, ctx); proxy == first {
+				t.Errorf("key %d still on quarantined %s", i, first)
+			}
+			p.mu.Lock()
+			for _, v := range p.proxies {
+				v.quarantinedUntil = time.Time{}
+				v.failures = 0
+			}
+			p.mu.Unlock()
+			if _, proxy := route(t, p, ctx); proxy != first {
+				t.Errorf("key %d on %s, want back on %s", i, proxy, first)
+			}
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("%v, want keys spread over all 3 proxies", seen)
+	}
+}
+
+func TestQuarantine(t *testing.T) {
+	p := newTestPool(t, func(c *config.Config) {
+		withProxies(RotationSticky, 2)(c)
+		c.Proxy.MaxFailures = 2
+		c.Proxy.Quarantine = time.Hour
+	})
+	ctx := WithKey(context.Background(), "UCalice")
+
+	req, first := route(t, p, ctx)
+	// not the proxy's fault
+	p.Report(req, fmt.Errorf("%w: https://consent.youtube.com/", fetcher.ErrConsent))
+	p.Report(req, &fetcher.StatusError{StatusCode: http.StatusNotFound})
+	p.Report(req, errBlocked)
+	if _, proxy := route(t, p, ctx); proxy != first {
+		t.Fatalf("quarantined after 1 failure, want %d", p.maxFailures)
+	}
+	// a success resets the failures in a row
+	p.Report(req, nil)
+	p.Report(req, errBlocked)
+	if _, proxy := route(t, p, ctx); proxy != first {
+		t.Fatal("quarantined after failures that were not in a row")
+	}
+	p.Report(req, errBlocked)
+
+	req, second := route(t, p, ctx)
+	if second == first {
+		t.Fatalf("still on %s after %d failures", first, p.maxFailures)
+	}
+	p.Report(req, errBlocked)
+	p.Report(req, errBlocked)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://www.youtube.invalid/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.Route(req); !errors.Is(err, ErrNoProxy) {
+		t.Errorf("%v, want ErrNoProxy", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusForbidden)
+	// an http proxy, the url asked for is absolute
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "www.youtube.invalid" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer proxyServer.Close()
+
+	p := newTestPool(t, func(c *config.Config) {
+		c.Proxy.List = []string{proxyServer.URL}
+		c.Proxy.CheckUrl = "http://www.youtube.invalid/"
+		c.Proxy.Quarantine = time.Hour
+	})
+	req, _ := route(t, p, context.Background())
+	p.Report(req, errBlocked)
+	pr := p.proxies[0]
+
+	p.check(pr)
+	p.mu.Lock()
+	quarantined := !pr.quarantinedUntil.IsZero()
+	p.mu.Unlock()
+	if !quarantined {
+		t.Fatal("back in rotation though the check failed")
+	}
+
+	status.Store(http.StatusOK)
+	p.check(pr)
+	if _, proxy := route(t, p, context.Background()); proxy != proxyServer.Listener.Addr().String() {
+		t.Errorf("routed through %q, want %s back in rotation", proxy, proxyServer.Listener.Addr())
+	}
+}
@@ -0,0 +1,151 @@
+package relativeTime
+
+import (
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/language"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unit of a relative time
+type Unit int
+
+const (
+	Second Unit = iota + 1
+	Minute
+	Hour
+	Day
+	Week
+	Month
+	Year
+)
+
+var numberRe = regexp.MustCompile(`\d+`)
+
+// units the words of the units of a language, lowercase. Stems are enough, "tag" is the unit of "Tagen"
+var units = map[string]map[string]Unit{
+	"en": {"second": Second, "minute": Minute, "hour": Hour, "day": Day, "week": Week, "month": Month, "year": Year},
+	"de": {"sekunde": Second, "minute": Minute, "stunde": Hour, "tag": Day, "woche": Week, "monat": Month, "jahr": Year},
+	"fr": {"seconde": Second, "minute": Minute, "heure": Hour, "jour": Day, "semaine": Week, "mois": Month, "an": Year},
+	"es": {"segundo": Second, "minuto": Minute, "hora": Hour, "día": Day, "dia": Day, "semana": Week, "mes": Month, "año": Year},
+	"pt": {"segundo": Second, "minuto": Minute, "hora": Hour, "dia": Day, "semana": Week, "mês": Month, "mes": Month, "ano": Year},
+	"it": {"second": Second, "minut": Minute, "ora": Hour, "ore": Hour, "giorn": Day, "settiman": Week, "mes": Month, "ann": Year},
+	"nl": {"seconde": Second, "minuut": Minute, "minuten": Minute, "uur": Hour, "dag": Day, "week": Week, "weken": Week, "maand": Month, "jaar": Year},
+	"sv": {"sekund": Second, "minut": Minute, "timm": Hour, "dag": Day, "veck": Week, "månad": Month, "år": Year},
+	"da": {"sekund": Second, "minut": Minute, "time": Hour, "dag": Day, "uge": Week, "måned": Month, "år": Year},
+	"nb": {"sekund": Second, "minutt": Minute, "time": Hour, "dag": Day, "uke": Week, "måned": Month, "år": Year},
+	"fi": {"sekunti": Second, "minuutti": Minute, "tunti": Hour, "päivä": Day, "viikko": Week, "kuukau": Month, "vuo": Year},
+	"pl": {"sekund": Second, "minut": Minute, "godzin": Hour, "dzień": Day, "dni": Day, "tydzień": Week, "tygodni": Week, "miesiąc": Month, "miesięc": Month, "rok": Year, "lat": Year},
+	"cs": {"sekund": Second, "minut": Minute, "hodin": Hour, "dn": Day, "den": Day, "týd": Week, "měsí": Month, "rok": Year, "let": Year},
+	"ro": {"secund": Second, "minut": Minute, "or": Hour, "zi": Day, "săptămân": Week, "lun": Month, "an": Year},
+	"hu": {"másodperc": Second, "perc": Minute, "ór": Hour, "nap": Day, "hét": Week, "het": Week, "hónap": Month, "év": Year},
+	"tr": {"saniye": Second, "dakika": Minute, "saat": Hour, "gün": Day, "hafta": Week, "ay": Month, "yıl": Year},
+	"ru": {"секунд": Second, "минут": Minute, "час": Hour, "дн": Day, "день": Day, "недел": Week, "месяц": Month, "год": Year, "лет": Year},
+	"uk": {"секунд": Second, "хвилин": Minute, "годин": Hour, "дн": Day, "день": Day, "тиж": Week, "місяц": Month, "рік": Year, "рок": Year},
+	"el": {"δευτερόλεπτ": Second, "λεπτ": Minute, "ώρ": Hour, "ημέρ": Day, "μέρ": Day, "εβδομάδ": Week, "μήν": Month, "μην": Month, "έτ": Year, "χρόν": Year},
+	"id": {"detik": Second, "menit": Minute, "jam": Hour, "hari": Day, "minggu": Week, "bulan": Month, "tahun": Year},
+	"ms": {"saat": Second, "minit": Minute, "jam": Hour, "hari": Day, "minggu": Week, "bulan": Month, "tahun": Year},
+	"vi": {"giây": Second, "phút": Minute, "giờ": Hour, "ngày": Day, "tuần": Week, "tháng": Month, "năm": Year},
+	"th": {"วินาที": Second, "นาที": Minute, "ชั่วโมง": Hour, "วัน": Day, "สัปดาห์": Week, "เดือน": Month, "ปี": Year},
+	"hi": {"सेकंड": Second, "मिनट": Minute, "घंटे": Hour, "घंटा": Hour, "दिन": Day, "सप्ताह": Week, "हफ़्त": Week, "महीन": Month, "वर्ष": Year, "साल": Year},
+	"ar": {"ثاني": Second, "ثوان": Second, "دقيق": Minute, "دقائق": Minute, "ساع": Hour, "يوم": Day, "أيام": Day, "أسبوع": Week, "أسابيع": Week, "شهر": Month, "أشهر": Month, "سنة": Year, "سنوات": Year},
+	"he": {"שני": Second, "דק": Minute, "שע": Hour, "יום": Day, "ימים": Day, "שבוע": Week, "חודש": Month, "שנ": Year},
+	"ja": {"秒": Second, "分": Minute, "時間": Hour, "日": Day, "週間": Week, "か月": Month, "ヶ月": Month, "カ月": Month, "年": Year},
+	"zh": {"秒": Second, "分钟": Minute, "分鐘": Minute, "小时": Hour, "小時": Hour, "天": Day, "周": Week, "週": Week, "个月": Month, "個月": Month, "年": Year},
+	"ko": {"초": Second, "분": Minute, "시간": Hour, "일": Day, "주": Week, "개월": Month, "년": Year},
+}
+
+// sortedUnits the words of each language, longest first so that "minuten" wins over "minut"
+var sortedUnits = language.SortedWords(units)
+
+// Estimate a point in time known only up to Uncertainty either way
+type Estimate struct {
+	Time        time.Time
+	Uncertainty time.Duration
+}
+
+// Earliest the earliest the time can be
+func (e Estimate) Earliest() time.Time {
+	return e.Time.Add(-e.Uncertainty)
+}
+
+// Latest the latest the time can be
+func (e Estimate) Latest() time.Time {
+	return e.Time.Add(e.Uncertainty)
+}
+
+// parse the amount and the unit of a relative time
+func parse(text string, hl string) (n int, unit Unit, err error) {
+	loc := numberRe.FindStringIndex(text)
+	if loc == nil {
+		err = fmt.Errorf("no relative time in %q", text)
+		return
+	}
+	n, err = strconv.Atoi(text[loc[0]:loc[1]])
+	if err != nil {
+		return
+	}
+
+	rest := strings.ToLower(strings.TrimLeft(text[loc[1]:], " \u00a0\u202f"))
+	for _, l := range language.Langs(hl) {
+		for _, v := range sortedUnits[l] {
+			if strings.HasPrefix(rest, v) {
+				unit = units[l][v]
+				return
+			}
+		}
+	}
+
+	err = fmt.Errorf("no unit in %q", text)
+	return
+}
+
+// back the time n units before now
+func back(now time.Time, n int, unit Unit) time.Time {
+	switch unit {
+	case Second:
+		return now.Add(-time.Duration(n) * time.Second)
+	case Minute:
+		return now.Add(-time.Duration(n) * time.Minute)
+	case Hour:
+		return now.Add(-time.Duration(n) * time.Hour)
+	case Day:
+		return now.AddDate(0, 0, -n)
+	case Week:
+		return now.AddDate(0, 0, -7*n)
+	case Month:
+		return addMonths(now, -n)
+	default:
+		return addMonths(now, -12*n)
+	}
+}
+
+// addMonths the same day months away, or the last day of that month if it is shorter.
+// AddDate would go on into the next month, a month before Mar 31 would be Mar 2
+func addMonths(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	first := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// Parse a relative time as YouTube shows it in the language hl, e.g. "3 weeks ago", "Streamed 2 days ago",
+// "vor 3 Tagen", "il y a 2 mois" or "2日前", into the time it stands for.
+// YouTube rounds down, "3 weeks ago" is anything from 3 weeks to just under 4 weeks before now,
+// the estimate is the middle of that span
+func Parse(text string, hl string, now time.Time) (estimate Estimate, err error) {
+	n, unit, err := parse(text, hl)
+	if err != nil {
+		return
+	}
+
+	latest := back(now, n, unit)
+	earliest := back(now, n+1, unit)
+	estimate.Uncertainty = latest.Sub(earliest) / 2
+	estimate.Time = earliest.Add(estimate.Uncertainty)
+	return
+}
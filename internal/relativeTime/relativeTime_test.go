@@ -0,0 +1,167 @@
+package relativeTime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUnit(t *testing.T) {
+	for _, v := range []struct {
+		hl   string
+		text string
+		n    int
+		unit Unit
+	}{
+		{"en", "3 weeks ago", 3, Week},
+		{"en", "1 year ago", 1, Year},
+		{"en", "2 years ago", 2, Year},
+		{"en", "Streamed 2 days ago", 2, Day},
+		{"en", "Premiered 5 hours ago", 5, Hour},
+		{"en", "10 minutes ago", 10, Minute},
+		{"en", "30 seconds ago", 30, Second},
+		{"en", "11 months ago (edited)", 11, Month},
+		{"", "4 days ago", 4, Day},
+		{"de", "vor 3 Tagen", 3, Day},
+		{"de", "Gestreamt vor 2 Wochen", 2, Week},
+		{"de", "vor 1 Jahr", 1, Year},
+		{"de", "vor 5 Monaten", 5, Month},
+		{"fr", "il y a 2 mois", 2, Month},
+		{"fr", "il y a 3 ans", 3, Year},
+		{"fr", "Diffusé il y a 4 heures", 4, Hour},
+		{"es", "hace 2 días", 2, Day},
+		{"es-419", "Transmitido hace 1 año", 1, Year},
+		{"es", "hace 6 meses", 6, Month},
+		{"pt-BR", "há 2 semanas", 2, Week},
+		{"pt-BR", "há 3 meses", 3, Month},
+		{"it", "2 giorni fa", 2, Day},
+		{"it", "1 anno fa", 1, Year},
+		{"it", "3 ore fa", 3, Hour},
+		{"nl", "2 dagen geleden", 2, Day},
+		{"nl", "10 minuten geleden", 10, Minute},
+		{"sv", "för 2 veckor sedan", 2, Week},
+		{"da", "for 3 måneder siden", 3, Month},
+		{"no", "for 1 år siden", 1, Year},
+		{"fi", "2 viikkoa sitten", 2, Week},
+		{"pl", "2 tygodnie temu", 2, Week},
+		{"pl", "5 lat temu", 5, Year},
+		{"pl", "3 miesiące temu", 3, Month},
+		{"cs", "před 2 dny", 2, Day},
+		{"ro", "acum 3 luni", 3, Month},
+		{"hu", "2 hete", 2, Week},
+		{"hu", "3 órája", 3, Hour},
+		{"tr", "2 hafta önce", 2, Week},
+		{"tr", "3 ay önce", 3, Month},
+		{"ru", "2 дня назад", 2, Day},
+		{"ru", "5 лет назад", 5, Year},
+		{"ru", "Трансляция закончилась 3 недели назад", 3, Week},
+		{"uk", "2 тижні тому", 2, Week},
+		{"el", "πριν από 2 ημέρες", 2, Day},
+		{"id", "2 hari yang lalu", 2, Day},
+		{"id", "Streaming 1 bulan yang lalu", 1, Month},
+		{"ms", "3 minggu lalu", 3, Week},
+		{"vi", "2 ngày trước", 2, Day},
+		{"vi", "1 năm trước", 1, Year},
+		{"th", "2 วันที่ผ่านมา", 2, Day},
+		{"hi", "2 दिन पहले", 2, Day},
+		{"hi", "3 महीने पहले", 3, Month},
+		{"ar", "قبل 3 أيام", 3, Day},
+		{"ar", "منذ 2 أسابيع", 2, Week},
+		{"he", "לפני 3 ימים", 3, Day},
+		{"ja", "2 日前", 2, Day},
+		{"ja", "3 か月前", 3, Month},
+		{"ja", "1年前", 1, Year},
+		{"ja", "5 時間前に配信済み", 5, Hour},
+		{"zh-CN", "2天前", 2, Day},
+		{"zh-CN", "3个月前", 3, Month},
+		{"zh-TW", "1 週前", 1, Week},
+		{"zh-TW", "10 分鐘前", 10, Minute},
+		{"ko", "2일 전", 2, Day},
+		{"ko", "3개월 전", 3, Month},
+		{"ko", "1년 전", 1, Year},
+	} {
+		n, unit, err := parse(v.text, v.hl)
+		if err != nil {
+			t.Errorf("%s %q: %v", v.hl, v.text, err)
+			continue
+		}
+		if n != v.n || unit != v.unit {
+			t.Errorf("%s %q: %d %d, want %d %d", v.hl, v.text, n, unit, v.n, v.unit)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	for _, v := range []struct {
+		hl          string
+		text        string
+		time        time.Time
+		uncertainty time.Duration
+	}{
+		{"en", "3 weeks ago", time.Date(2024, 2, 20, 0, 0, 0, 0, time.UTC), time.Hour * 84},
+		{"en", "1 month ago", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Hour * 372},
+		{"en", "1 year ago", time.Date(2022, 9, 14, 0, 0, 0, 0, time.UTC), time.Hour * 4380},
+		{"en", "Streamed 30 seconds ago", time.Date(2024, 3, 15, 11, 59, 29, 500000000, time.UTC), time.Millisecond * 500},
+		{"de", "vor 2 Stunden", time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC), time.Minute * 30},
+		{"ja", "2日前", time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC), time.Hour * 12},
+		// English pages whatever the language asked for
+		{"fr", "2 weeks ago", time.Date(2024, 2, 27, 0, 0, 0, 0, time.UTC), time.Hour * 84},
+	} {
+		estimate, err := Parse(v.text, v.hl, now)
+		if err != nil {
+			t.Errorf("%s %q: %v", v.hl, v.text, err)
+			continue
+		}
+		if !estimate.Time.Equal(v.time) || estimate.Uncertainty != v.uncertainty {
+			t.Errorf("%s %q: %s ± %s, want %s ± %s", v.hl, v.text, estimate.Time, estimate.Uncertainty, v.time, v.uncertainty)
+		}
+		if estimate.Latest().After(now) {
+			t.Errorf("%s %q: latest %s after now", v.hl, v.text, estimate.Latest())
+		}
+	}
+}
+
+func TestBack(t *testing.T) {
+	for _, v := range []struct {
+		now  time.Time
+		n    int
+		unit Unit
+		want time.Time
+	}{
+		{time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC), 1, Month, time.Date(2024, 2, 15, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), 1, Month, time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 3, 31, 12, 0, 0, 0, time.UTC), 1, Month, time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC), 1, Month, time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), 2, Month, time.Date(2023, 11, 30, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), 1, Year, time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), 1, Day, time.Date(2024, 3, 30, 12, 0, 0, 0, time.UTC)},
+	} {
+		if got := back(v.now, v.n, v.unit); !got.Equal(v.want) {
+			t.Errorf("%s - %d %d: %s, want %s", v.now, v.n, v.unit, got, v.want)
+		}
+	}
+}
+
+func TestParseMonthEnd(t *testing.T) {
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+	estimate, err := Parse("1 month ago", "en", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// between Jan 31 and Feb 29, not between Mar 2 and Mar 3
+	want := time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC)
+	if !estimate.Time.Equal(want) || estimate.Uncertainty != time.Hour*348 {
+		t.Errorf("%s ± %s, want %s ± %s", estimate.Time, estimate.Uncertainty, want, time.Hour*348)
+	}
+	if estimate.Latest().After(now) {
+		t.Errorf("latest %s after now", estimate.Latest())
+	}
+}
+
+func TestParseNoTime(t *testing.T) {
+	for _, v := range []string{"", "Live", "Premieres soon", "3 views"} {
+		if _, err := Parse(v, "en", time.Now()); err == nil {
+			t.Errorf("%q: no error", v)
+		}
+	}
+}
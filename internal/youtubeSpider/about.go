@@ -0,0 +1,160 @@
+package youtubeSpider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/config"
+	"github.com/lizongying/go-youtube/internal/proxyPool"
+	"github.com/lizongying/go-youtube/internal/utils"
+	"golang.org/x/net/context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redirectTarget the target of a youtube.com/redirect link, the link itself otherwise
+func redirectTarget(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	if u.Path == "/redirect" {
+		if q := u.Query().Get("q"); q != "" {
+			return q
+		}
+	}
+	return link
+}
+
+// joinedLayouts the layouts of the joined date of English pages, in the US and elsewhere
+var joinedLayouts = []string{"Jan 2, 2006", "2 Jan 2006"}
+
+// joinedAt the joined date of the About tab, zero when the page is not in English
+func joinedAt(joined string) (t time.Time) {
+	for _, v := range joinedLayouts {
+		parsed, err := time.Parse(v, strings.TrimSpace(joined))
+		if err == nil {
+			t = parsed
+			return
+		}
+	}
+	return
+}
+
+// About fetch the About tab of a channel
+func (y *YoutubeSpider) About(ctx context.Context, meta MetaUser) (about *About, err error) {
+	y.logger.Info("About", utils.JsonStr(meta))
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = proxyPool.WithKey(ctx, meta.Id)
+
+	u := fmt.Sprintf(y.urlAbout, meta.Id)
+	if meta.Id == "" {
+		u = fmt.Sprintf(y.urlChannelAbout, meta.Key)
+	}
+	body, err := y.innertube.Get(ctx, u)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	r := y.initialDataRe.FindSubmatch(body)
+	if len(r) != 2 {
+		err = errors.New("not find content")
+		y.logger.Error(err)
+		return
+	}
+	var respAbout RespAbout
+	err = json.Unmarshal(r[1], &respAbout)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	for _, v := range respAbout.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		for _, v1 := range v.TabRenderer.Content.SectionListRenderer.Contents {
+			for _, v2 := range v1.ItemSectionRenderer.Contents {
+				metadata := v2.ChannelAboutFullMetadataRenderer
+				if metadata.ChannelID == "" {
+					continue
+				}
+
+				about = &About{
+					Description:      strings.TrimSpace(metadata.Description.SimpleText),
+					Country:          metadata.Country.SimpleText,
+					HasBusinessEmail: metadata.BusinessEmailRevealButton != nil || metadata.BusinessEmailLabel.SimpleText != "",
+				}
+
+				for _, v3 := range metadata.PrimaryLinks {
+					link := v3.NavigationEndpoint.URLEndpoint.URL
+					if link == "" {
+						continue
+					}
+					about.Links = append(about.Links, AboutLink{
+						Title: v3.Title.SimpleText,
+						Url:   redirectTarget(link),
+					})
+				}
+
+				// "Joined ", "Mar 3, 2014"
+				runs := metadata.JoinedDateText.Runs
+				if len(runs) > 0 {
+					joined := runs[len(runs)-1].Text
+					about.JoinedAt = joinedAt(joined)
+					if about.JoinedAt.IsZero() {
+						y.logger.Debug("joined not english", joined)
+					}
+				}
+
+				viewCountText := metadata.ViewCountText.SimpleText
+				if viewCountText != "" {
+					totalViews, e := strconv.Atoi(strings.Join(y.intRe.FindAllString(viewCountText, -1), ""))
+					if e != nil {
+						y.logger.Error(e, "viewCount", viewCountText)
+					}
+					about.TotalViews = totalViews
+				}
+
+				return
+			}
+		}
+	}
+
+	err = errors.New("not find about")
+	y.logger.Error(err)
+	return
+}
+
+// addAbout complete data with the About tab of the channel.
+// Without the tab data keeps what the description gave, and is incomplete if rules ask for a country or an email.
+// The crawl goes on then, the error is the one of a cancelled ctx
+func (y *YoutubeSpider) addAbout(ctx context.Context, rules *config.Rules, meta MetaUser, data *Data) (err error) {
+	about, e := y.About(ctx, meta)
+	if e != nil {
+		data.incomplete = data.incomplete || len(rules.Countries) > 0 || rules.RequireEmail && data.Email == ""
+		err = ctx.Err()
+		return
+	}
+
+	data.aboutFetched = true
+	data.Links = about.Links
+	data.Country = about.Country
+	data.JoinedAt = about.JoinedAt
+	data.TotalViews = about.TotalViews
+	data.HasBusinessEmail = about.HasBusinessEmail
+
+	// the links the channel lists itself beat a url found in the description
+	if len(about.Links) > 0 {
+		data.Link = about.Links[0].Url
+	}
+	var links []string
+	for _, v := range about.Links {
+		links = append(links, v.Url)
+	}
+	y.addContacts(data, about.Description, links...)
+
+	return
+}
@@ -0,0 +1,217 @@
+package youtubeSpider
+
+import (
+	"github.com/lizongying/go-youtube/internal/innertube"
+	"github.com/lizongying/go-youtube/internal/utils"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+// Comments crawl the comment threads of a video and their replies,
+// up to meta.MaxPage pages of threads if it is set
+func (y *YoutubeSpider) Comments(ctx context.Context, meta MetaVideo) (err error) {
+	y.logger.Info("Comments", utils.JsonStr(meta))
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	resp, err := y.innertube.Next(ctx, meta.Id)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	var respNext RespNext
+	err = resp.Decode(&respNext)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	token := ""
+	for _, v := range respNext.Contents.TwoColumnWatchNextResults.Results.Results.Contents {
+		if v.ItemSectionRenderer.SectionIdentifier != "comment-item-section" {
+			continue
+		}
+		for _, v1 := range v.ItemSectionRenderer.Contents {
+			if t := v1.ContinuationItemRenderer.ContinuationEndpoint.ContinuationCommand.Token; t != "" {
+				token = t
+			}
+		}
+	}
+	if token == "" {
+		y.logger.Info("comments off", meta.Id)
+		return
+	}
+
+	w := new(walk)
+	for page := 1; token != ""; page++ {
+		if meta.MaxPage > 0 && page > meta.MaxPage {
+			y.logger.Info("max page")
+			break
+		}
+
+		var items []CommentItem
+		items, err = y.commentItems(ctx, token)
+		if err != nil {
+			y.logger.Error(err)
+			return
+		}
+
+		token = ""
+		var comments []*CommentData
+		for _, v := range items {
+			if t := v.token(); t != "" {
+				token = t
+				continue
+			}
+
+			thread := v.CommentThreadRenderer
+			comment := y.commentData(meta, &thread.Comment.CommentRenderer, "")
+			if comment.Id == "" {
+				continue
+			}
+			comments = append(comments, comment)
+
+			if !y.commentReplies {
+				continue
+			}
+			for _, v1 := range thread.Replies.CommentRepliesRenderer.Contents {
+				if t := v1.token(); t != "" {
+					var replies []*CommentData
+					replies, err = y.replies(ctx, meta, comment.Id, t)
+					if err != nil {
+						y.logger.Error(err)
+						return
+					}
+					comments = append(comments, replies...)
+				}
+			}
+		}
+
+		err = y.saveComments(ctx, comments)
+		if err != nil {
+			y.logger.Error(err)
+			return
+		}
+
+		if !w.next(token, len(comments)) {
+			break
+		}
+	}
+
+	return
+}
+
+// replies follow the reply continuations of a thread
+func (y *YoutubeSpider) replies(ctx context.Context, meta MetaVideo, parentId string, token string) (replies []*CommentData, err error) {
+	w := new(walk)
+	for token != "" {
+		var items []CommentItem
+		items, err = y.commentItems(ctx, token)
+		if err != nil {
+			return
+		}
+
+		n := len(replies)
+		token = ""
+		for _, v := range items {
+			if t := v.token(); t != "" {
+				token = t
+				continue
+			}
+
+			reply := y.commentData(meta, &v.CommentRenderer, parentId)
+			if reply.Id == "" {
+				continue
+			}
+			replies = append(replies, reply)
+		}
+
+		if !w.next(token, len(replies)-n) {
+			break
+		}
+	}
+
+	return
+}
+
+// commentItems the items of a comments continuation, whether it reloads the section or appends to it
+func (y *YoutubeSpider) commentItems(ctx context.Context, token string) (items []CommentItem, err error) {
+	resp, err := y.innertube.Continue(ctx, innertube.Continuation{
+		Endpoint: innertube.EndpointNext,
+		Token:    token,
+	})
+	if err != nil {
+		return
+	}
+
+	var respComments RespComments
+	err = resp.Decode(&respComments)
+	if err != nil {
+		return
+	}
+
+	for _, v := range respComments.OnResponseReceivedEndpoints {
+		// the first page also reloads the header of the comments section
+		if v.ReloadContinuationItemsCommand.TargetID == "comments-section" {
+			continue
+		}
+		items = append(items, v.ReloadContinuationItemsCommand.ContinuationItems...)
+		items = append(items, v.AppendContinuationItemsAction.ContinuationItems...)
+	}
+
+	return
+}
+
+func (y *YoutubeSpider) commentData(meta MetaVideo, renderer *CommentRenderer, parentId string) (comment *CommentData) {
+	var text strings.Builder
+	for _, v := range renderer.ContentText.Runs {
+		text.WriteString(v.Text)
+	}
+
+	publishedTime := ""
+	if runs := renderer.PublishedTimeText.Runs; len(runs) > 0 {
+		publishedTime = runs[0].Text
+	}
+
+	likeCount, _ := y.parseCount(renderer.VoteCount.SimpleText)
+	published, _ := y.published(publishedTime)
+
+	comment = &CommentData{
+		Id:                   renderer.CommentID,
+		VideoId:              meta.Id,
+		ChannelId:            meta.ChannelId,
+		ParentId:             parentId,
+		AuthorId:             renderer.AuthorEndpoint.BrowseEndpoint.BrowseID,
+		AuthorHandle:         strings.TrimPrefix(renderer.AuthorEndpoint.BrowseEndpoint.CanonicalBaseURL, "/@"),
+		AuthorName:           renderer.AuthorText.SimpleText,
+		Text:                 text.String(),
+		LikeCount:            likeCount,
+		ReplyCount:           renderer.ReplyCount,
+		PublishedTime:        publishedTime,
+		PublishedAt:          published.Time,
+		PublishedUncertainty: published.Uncertainty,
+		CrawledAt:            time.Now(),
+	}
+	return
+}
+
+func (y *YoutubeSpider) saveComments(ctx context.Context, comments []*CommentData) (err error) {
+	if len(comments) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, y.timeout)
+	defer cancel()
+
+	n, err := y.store.SaveComments(ctx, comments)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	y.logger.Info("save comments success", n)
+
+	return
+}
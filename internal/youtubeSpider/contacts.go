@@ -0,0 +1,213 @@
+package youtubeSpider
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	SocialInstagram = "instagram"
+	SocialTiktok    = "tiktok"
+	SocialX         = "x"
+	SocialFacebook  = "facebook"
+	SocialTwitch    = "twitch"
+	SocialLinktree  = "linktree"
+	SocialSite      = "site"
+)
+
+// the profile url of a handle on each platform
+var socialUrls = map[string]string{
+	SocialInstagram: "https://www.instagram.com/",
+	SocialTiktok:    "https://www.tiktok.com/@",
+	SocialX:         "https://x.com/",
+	SocialFacebook:  "https://www.facebook.com/",
+	SocialTwitch:    "https://www.twitch.tv/",
+	SocialLinktree:  "https://linktr.ee/",
+}
+
+// the platform of each host
+var socialHosts = map[string]string{
+	"instagram.com":     SocialInstagram,
+	"instagr.am":        SocialInstagram,
+	"tiktok.com":        SocialTiktok,
+	"twitter.com":       SocialX,
+	"x.com":             SocialX,
+	"facebook.com":      SocialFacebook,
+	"m.facebook.com":    SocialFacebook,
+	"fb.com":            SocialFacebook,
+	"twitch.tv":         SocialTwitch,
+	"linktr.ee":         SocialLinktree,
+	"youtube.com":       "",
+	"m.youtube.com":     "",
+	"youtu.be":          "",
+	"music.youtube.com": "",
+}
+
+// the hosts of shorteners, affiliate links, shops and streaming services, links there are not the site of a channel.
+// Subdomains are matched too
+var notSites = map[string]bool{
+	"bit.ly": true, "tinyurl.com": true, "t.co": true, "goo.gl": true, "ow.ly": true, "buff.ly": true,
+	"rebrand.ly": true, "cutt.ly": true, "shorturl.at": true, "is.gd": true, "tiny.cc": true, "lnkd.in": true,
+	"amzn.to": true, "amzn.eu": true, "a.co": true, "geni.us": true, "rstyle.me": true, "shopstyle.it": true,
+	"liketoknow.it": true, "shopltk.com": true, "shopmy.us": true, "howl.me": true, "go.magik.ly": true,
+	"amazon.com": true, "amazon.co.uk": true, "amazon.de": true, "amazon.fr": true, "amazon.es": true,
+	"amazon.it": true, "amazon.ca": true, "amazon.in": true, "amazon.co.jp": true, "amazon.com.br": true,
+	"etsy.com": true, "myshopify.com": true, "shop.app": true, "teespring.com": true, "creator-spring.com": true,
+	"spotify.com": true, "spotify.link": true, "apple.com": true, "apple.co": true, "soundcloud.com": true,
+	"deezer.com": true, "tidal.com": true, "play.google.com": true,
+}
+
+// notSite if a host is a shortener, a shop or a service rather than the site of a channel
+func notSite(host string) bool {
+	for {
+		if notSites[host] {
+			return true
+		}
+		_, parent, ok := strings.Cut(host, ".")
+		if !ok || !strings.Contains(parent, ".") {
+			return false
+		}
+		host = parent
+	}
+}
+
+// the first path segments of a platform that are pages, not profiles
+var socialPages = map[string]bool{
+	"p": true, "reel": true, "reels": true, "stories": true, "explore": true, "video": true, "tag": true,
+	"share": true, "sharer": true, "watch": true, "groups": true, "events": true, "intent": true,
+	"hashtag": true, "i": true, "home": true, "search": true, "videos": true,
+}
+
+var (
+	// "name [at] gmail [dot] com", "name(at)gmail(dot)com", "name＠gmail.com"
+	obfuscatedAtRe  = regexp.MustCompile(`(?i)\s*[\[({<]\s*(?:at|@)\s*[\])}>]\s*|＠`)
+	obfuscatedDotRe = regexp.MustCompile(`(?i)\s*[\[({<]\s*(?:dot|\.)\s*[\])}>]\s*`)
+	// "IG: @alice", "TikTok - alice.beauty"
+	socialMentionRe = regexp.MustCompile(`(?i)\b(instagram|insta|ig|tiktok|twitter|twitch)\s*[:\-–]\s*@?([\w.]*\w)`)
+)
+
+var socialMentions = map[string]string{
+	"instagram": SocialInstagram,
+	"insta":     SocialInstagram,
+	"ig":        SocialInstagram,
+	"tiktok":    SocialTiktok,
+	"twitter":   SocialX,
+	"twitch":    SocialTwitch,
+}
+
+// social the profile a link leads to, false if it leads to YouTube, a shortener or a shop, or is not a web link
+func social(link string) (s Social, ok bool) {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	platform, known := socialHosts[host]
+	if known && platform == "" {
+		return
+	}
+	if !known {
+		if notSite(host) {
+			return
+		}
+		s = Social{
+			Platform: SocialSite,
+			Handle:   host,
+			Url:      "https://" + host + strings.TrimSuffix(u.Path, "/"),
+		}
+		ok = true
+		return
+	}
+
+	segment, _, _ := strings.Cut(strings.Trim(u.Path, "/"), "/")
+	segment = strings.ToLower(segment)
+	if platform == SocialTiktok {
+		if !strings.HasPrefix(segment, "@") {
+			return
+		}
+	}
+	segment = strings.TrimPrefix(segment, "@")
+	profileUrl := socialUrls[platform] + segment
+	// profiles without a username are known by their id
+	if platform == SocialFacebook && segment == "profile.php" {
+		segment = u.Query().Get("id")
+		profileUrl = socialUrls[platform] + "profile.php?id=" + segment
+	}
+	if segment == "" || socialPages[segment] {
+		return
+	}
+
+	s = Social{
+		Platform: platform,
+		Handle:   segment,
+		Url:      profileUrl,
+	}
+	ok = true
+	return
+}
+
+// addSocial add s to data unless it is there already
+func addSocial(data *Data, s Social) {
+	for _, v := range data.Socials {
+		if v.Platform == s.Platform && v.Handle == s.Handle {
+			return
+		}
+	}
+	data.Socials = append(data.Socials, s)
+}
+
+// addEmail add an email to data unless it is there already, the first one is the Email of data
+func addEmail(data *Data, email string) {
+	email = strings.ToLower(strings.TrimRight(email, "."))
+	for _, v := range data.Emails {
+		if v == email {
+			return
+		}
+	}
+	data.Emails = append(data.Emails, email)
+	if data.Email == "" {
+		data.Email = email
+	}
+}
+
+// addContacts add the emails and the social profiles of a text, like a description, and of links to data.
+// The first link is the Link of data if it has none yet
+func (y *YoutubeSpider) addContacts(data *Data, text string, links ...string) {
+	text = obfuscatedDotRe.ReplaceAllString(obfuscatedAtRe.ReplaceAllString(text, "@"), ".")
+	for _, v := range y.emailRe.FindAllString(text, -1) {
+		addEmail(data, v)
+	}
+
+	for _, v := range socialMentionRe.FindAllStringSubmatchIndex(text, -1) {
+		// "Instagram: https://..." is a link, read below
+		if rest := text[v[1]:]; strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, "/") {
+			continue
+		}
+		platform := socialMentions[strings.ToLower(text[v[2]:v[3]])]
+		handle := strings.ToLower(text[v[4]:v[5]])
+		addSocial(data, Social{
+			Platform: platform,
+			Handle:   handle,
+			Url:      socialUrls[platform] + handle,
+		})
+	}
+
+	links = append(y.urlRe.FindAllString(text, -1), links...)
+	for _, v := range links {
+		if email, ok := strings.CutPrefix(v, "mailto:"); ok {
+			addEmail(data, email)
+			continue
+		}
+		if data.Link == "" {
+			data.Link = v
+		}
+		if s, ok := social(v); ok {
+			addSocial(data, s)
+		}
+	}
+}
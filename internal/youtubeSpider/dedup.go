@@ -0,0 +1,112 @@
+package youtubeSpider
+
+import (
+	"golang.org/x/net/context"
+	"sync"
+	"time"
+)
+
+type ctxKey int
+
+const (
+	ctxKeySeen ctxKey = iota
+	ctxKeyRules
+)
+
+// seen the channels of a job, evaluated or queued to be
+type seen struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+// withSeen start a job, channels are evaluated once within it
+func withSeen(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxKeySeen, &seen{
+		keys: make(map[string]struct{}),
+	})
+}
+
+// markSeen mark a channel in the job of ctx, false if it was already.
+// Outside of a job every channel is new
+func markSeen(ctx context.Context, key string) bool {
+	s, ok := ctx.Value(ctxKeySeen).(*seen)
+	if !ok {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok = s.keys[key]; ok {
+		return false
+	}
+	s.keys[key] = struct{}{}
+	return true
+}
+
+// channelKey the channel id, or the handle when the id is not known
+func channelKey(meta MetaUser) string {
+	if meta.Key != "" {
+		return meta.Key
+	}
+	return meta.Id
+}
+
+// skip if the channel was evaluated in this job already, or under the same rules by a job within the freshness window.
+// A channel skipped as fresh still gets the keyword of the job
+func (y *YoutubeSpider) skip(ctx context.Context, meta MetaUser) bool {
+	key := channelKey(meta)
+	if !markSeen(ctx, key) {
+		y.logger.Debug("seen", key)
+		return true
+	}
+	if y.freshness <= 0 {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, y.timeout)
+	defer cancel()
+
+	evaluated, err := y.store.Evaluated(ctx, key, time.Now().Add(-y.freshness))
+	if err != nil {
+		y.logger.Error(err)
+		return false
+	}
+	if evaluated == nil || evaluated.RulesHash != rulesHash(y.rulesOf(ctx)) {
+		return false
+	}
+	y.logger.Debug("fresh", key, evaluated.EvaluatedAt)
+
+	if meta.KeyWord != "" && meta.Id != "" {
+		err = y.store.AddKeyword(ctx, meta.Id, meta.KeyWord)
+		if err != nil {
+			y.logger.Error(err)
+		}
+	}
+	return true
+}
+
+// evaluate a channel and record when, for later jobs to skip it.
+// A channel missing what the rules need, like a country of an About tab that failed, is not recorded
+func (y *YoutubeSpider) evaluate(ctx context.Context, meta MetaUser) (err error) {
+	complete, err := y.videos(ctx, meta)
+	if err != nil || !complete {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, y.timeout)
+	defer cancel()
+
+	key := channelKey(meta)
+	err = y.store.SaveEvaluated(ctx, &Evaluated{
+		Id:          key,
+		EvaluatedAt: time.Now(),
+		RulesHash:   rulesHash(y.rulesOf(ctx)),
+	})
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,210 @@
+package youtubeSpider
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/net/context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore a Store in memory, for tests and dry runs.
+// Channels are kept as the documents mongo would hold
+type MemoryStore struct {
+	mu        sync.Mutex
+	users     map[string]bson.M
+	videos    map[string]*VideoData
+	comments  map[string]*CommentData
+	playlists map[string]*PlaylistData
+	evaluated map[string]*Evaluated
+	snapshots []*Snapshot
+}
+
+func (s *MemoryStore) UpsertUser(_ context.Context, id string, set bson.M, setOnInsert bson.M, keywords []string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		user = bson.M{"_id": id}
+		for k, v := range setOnInsert {
+			user[k] = v
+		}
+		s.users[id] = user
+	}
+	for k, v := range set {
+		user[k] = v
+	}
+
+	addKeywords(user, keywords...)
+	return
+}
+
+func (s *MemoryStore) AddKeyword(_ context.Context, id string, keyword string) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, ok := s.users[id]; ok {
+		addKeywords(user, keyword)
+	}
+	return
+}
+
+// addKeywords add keywords to the keywords of a user, as $addToSet does
+func addKeywords(user bson.M, keywords ...string) {
+	existing, _ := user["keywords"].([]string)
+next:
+	for _, v := range keywords {
+		for _, v1 := range existing {
+			if v1 == v {
+				continue next
+			}
+		}
+		existing = append(existing, v)
+	}
+	if existing != nil {
+		user["keywords"] = existing
+	}
+}
+
+func (s *MemoryStore) SaveVideo(_ context.Context, data *VideoData) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.videos[data.Id] = data
+	return
+}
+
+func (s *MemoryStore) SaveComments(_ context.Context, comments []*CommentData) (n int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range comments {
+		s.comments[v.Id] = v
+		n++
+	}
+	return
+}
+
+func (s *MemoryStore) SavePlaylist(_ context.Context, data *PlaylistData) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.playlists[data.Id] = data
+	return
+}
+
+func (s *MemoryStore) Evaluated(_ context.Context, key string, after time.Time) (evaluated *Evaluated, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.evaluated[key]; ok && v.EvaluatedAt.After(after) {
+		evaluated = v
+	}
+	return
+}
+
+func (s *MemoryStore) SaveEvaluated(_ context.Context, evaluated *Evaluated) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evaluated[evaluated.Id] = evaluated
+	return
+}
+
+func (s *MemoryStore) SaveSnapshot(_ context.Context, snapshot *Snapshot) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots = append(s.snapshots, snapshot)
+	return
+}
+
+func (s *MemoryStore) Snapshots(_ context.Context, channelId string, since time.Time) (snapshots []*Snapshot, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, v := range s.snapshots {
+		if v.ChannelId == channelId && !v.CrawledAt.Before(since) {
+			snapshots = append(snapshots, v)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].CrawledAt.Before(snapshots[j].CrawledAt)
+	})
+	return
+}
+
+// User the channel saved as id, as it would be read back from mongo
+func (s *MemoryStore) User(id string) (data *Data, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return
+	}
+	bs, err := bson.Marshal(user)
+	if err != nil {
+		ok = false
+		return
+	}
+	data = new(Data)
+	if bson.Unmarshal(bs, data) != nil {
+		ok = false
+	}
+	return
+}
+
+// Users the ids of the channels saved
+func (s *MemoryStore) Users() (ids []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k := range s.users {
+		ids = append(ids, k)
+	}
+	sort.Strings(ids)
+	return
+}
+
+// Video the video saved as id
+func (s *MemoryStore) Video(id string) (data *VideoData, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok = s.videos[id]
+	return
+}
+
+// Comments the comments saved, by id
+func (s *MemoryStore) Comments() (comments map[string]*CommentData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comments = make(map[string]*CommentData, len(s.comments))
+	for k, v := range s.comments {
+		comments[k] = v
+	}
+	return
+}
+
+// Playlist the playlist saved as id
+func (s *MemoryStore) Playlist(id string) (data *PlaylistData, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok = s.playlists[id]
+	return
+}
+
+func NewMemoryStore() (store *MemoryStore) {
+	store = &MemoryStore{
+		users:     make(map[string]bson.M),
+		videos:    make(map[string]*VideoData),
+		comments:  make(map[string]*CommentData),
+		playlists: make(map[string]*PlaylistData),
+		evaluated: make(map[string]*Evaluated),
+	}
+	return
+}
@@ -0,0 +1,252 @@
+package youtubeSpider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/innertube"
+	"github.com/lizongying/go-youtube/internal/pool"
+	"github.com/lizongying/go-youtube/internal/proxyPool"
+	"github.com/lizongying/go-youtube/internal/utils"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Playlist fetch a playlist with all its videos, up to playlistMaxPage pages if it is set, and save it
+func (y *YoutubeSpider) Playlist(ctx context.Context, playlistId string) (data *PlaylistData, err error) {
+	y.logger.Info("Playlist", playlistId)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	resp, err := y.innertube.Browse(ctx, "VL"+playlistId, "")
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	var respPlaylist RespPlaylist
+	err = resp.Decode(&respPlaylist)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	header := respPlaylist.Header.PlaylistHeaderRenderer
+	data = &PlaylistData{
+		Id:        playlistId,
+		Title:     header.Title.SimpleText,
+		CrawledAt: time.Now(),
+	}
+	if runs := header.NumVideosText.Runs; len(runs) > 0 {
+		data.VideoCount, _ = y.parseCount(runs[0].Text)
+	}
+	if runs := header.OwnerText.Runs; len(runs) > 0 {
+		data.ChannelId = runs[0].NavigationEndpoint.BrowseEndpoint.BrowseID
+		data.ChannelHandle = strings.TrimPrefix(runs[0].NavigationEndpoint.BrowseEndpoint.CanonicalBaseURL, "/@")
+		data.ChannelName = runs[0].Text
+	}
+
+	var items []PlaylistItem
+	for _, v := range respPlaylist.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		for _, v1 := range v.TabRenderer.Content.SectionListRenderer.Contents {
+			for _, v2 := range v1.ItemSectionRenderer.Contents {
+				items = append(items, v2.PlaylistVideoListRenderer.Contents...)
+			}
+		}
+	}
+
+	w := new(walk)
+	for page := 1; ; page++ {
+		n := len(data.Videos)
+		token := ""
+		for _, v := range items {
+			if t := v.ContinuationItemRenderer.ContinuationEndpoint.ContinuationCommand.Token; t != "" {
+				token = t
+				continue
+			}
+
+			video := v.PlaylistVideoRenderer
+			if video.VideoID == "" {
+				continue
+			}
+			var title strings.Builder
+			for _, v1 := range video.Title.Runs {
+				title.WriteString(v1.Text)
+			}
+			duration, _ := strconv.Atoi(video.LengthSeconds)
+			playlistVideo := &PlaylistVideo{
+				Id:       video.VideoID,
+				Title:    title.String(),
+				Duration: duration,
+			}
+			if runs := video.ShortBylineText.Runs; len(runs) > 0 {
+				playlistVideo.ChannelId = runs[0].NavigationEndpoint.BrowseEndpoint.BrowseID
+				playlistVideo.ChannelHandle = strings.TrimPrefix(runs[0].NavigationEndpoint.BrowseEndpoint.CanonicalBaseURL, "/@")
+				playlistVideo.ChannelName = runs[0].Text
+			}
+			data.Videos = append(data.Videos, playlistVideo)
+		}
+
+		if y.playlistMaxPage > 0 && page >= y.playlistMaxPage && token != "" {
+			y.logger.Info("max page")
+			break
+		}
+		if !w.next(token, len(data.Videos)-n) {
+			break
+		}
+
+		items, err = y.playlistItems(ctx, token)
+		if err != nil {
+			y.logger.Error(err)
+			return
+		}
+	}
+	y.logger.Debug(utils.JsonStr(data))
+
+	err = y.savePlaylist(ctx, data)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	return
+}
+
+// playlistItems the items appended to a playlist by a browse continuation
+func (y *YoutubeSpider) playlistItems(ctx context.Context, token string) (items []PlaylistItem, err error) {
+	resp, err := y.innertube.Continue(ctx, innertube.Continuation{
+		Endpoint: innertube.EndpointBrowse,
+		Token:    token,
+	})
+	if err != nil {
+		return
+	}
+
+	var respPlaylist RespPlaylistContinuation
+	err = resp.Decode(&respPlaylist)
+	if err != nil {
+		return
+	}
+
+	for _, v := range respPlaylist.OnResponseReceivedActions {
+		items = append(items, v.AppendContinuationItemsAction.ContinuationItems...)
+	}
+
+	return
+}
+
+// Playlists list the playlists of the Playlists tab of a channel, without their videos
+func (y *YoutubeSpider) Playlists(ctx context.Context, meta MetaUser) (playlists []*PlaylistData, err error) {
+	y.logger.Info("Playlists", utils.JsonStr(meta))
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = proxyPool.WithKey(ctx, meta.Id)
+
+	body, err := y.innertube.Get(ctx, fmt.Sprintf(y.urlPlaylists, meta.Id))
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	r := y.initialDataRe.FindSubmatch(body)
+	if len(r) != 2 {
+		err = errors.New("not find content")
+		y.logger.Error(err)
+		return
+	}
+	var respPlaylists RespPlaylists
+	err = json.Unmarshal(r[1], &respPlaylists)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	for _, v := range respPlaylists.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		if !v.TabRenderer.Selected {
+			continue
+		}
+		for _, v1 := range v.TabRenderer.Content.SectionListRenderer.Contents {
+			for _, v2 := range v1.ItemSectionRenderer.Contents {
+				for _, v3 := range v2.GridRenderer.Items {
+					playlist := &PlaylistData{
+						ChannelId:     meta.Key,
+						ChannelHandle: meta.Id,
+						ChannelName:   meta.UserName,
+					}
+					switch {
+					case v3.GridPlaylistRenderer.PlaylistID != "":
+						playlist.Id = v3.GridPlaylistRenderer.PlaylistID
+						var title strings.Builder
+						for _, v4 := range v3.GridPlaylistRenderer.Title.Runs {
+							title.WriteString(v4.Text)
+						}
+						playlist.Title = title.String()
+						playlist.VideoCount, _ = y.parseCount(v3.GridPlaylistRenderer.VideoCountShortText.SimpleText)
+					case v3.LockupViewModel.ContentType == "LOCKUP_CONTENT_TYPE_PLAYLIST":
+						playlist.Id = v3.LockupViewModel.ContentID
+						playlist.Title = v3.LockupViewModel.Metadata.LockupMetadataViewModel.Title.Content
+					default:
+						continue
+					}
+					playlists = append(playlists, playlist)
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// submitPlaylist crawl a playlist, then queue the evaluation of its owner and of the channels of its videos.
+// The playlist is crawled by the caller, tasks can not submit tasks. A playlist that fails is skipped
+func (y *YoutubeSpider) submitPlaylist(ctx context.Context, p *pool.Pool, keyword string, playlistId string) (err error) {
+	data, e := y.Playlist(ctx, playlistId)
+	if e != nil {
+		err = ctx.Err()
+		return
+	}
+
+	metas := []MetaUser{{
+		KeyWord:  keyword,
+		Id:       data.ChannelHandle,
+		Key:      data.ChannelId,
+		UserName: data.ChannelName,
+	}}
+	for _, v := range data.Videos {
+		metas = append(metas, MetaUser{
+			KeyWord:  keyword,
+			Id:       v.ChannelHandle,
+			Key:      v.ChannelId,
+			UserName: v.ChannelName,
+		})
+	}
+
+	for _, v := range metas {
+		if v.Id == "" {
+			continue
+		}
+		err = y.submitVideos(ctx, p, v)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (y *YoutubeSpider) savePlaylist(ctx context.Context, data *PlaylistData) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, y.timeout)
+	defer cancel()
+
+	err = y.store.SavePlaylist(ctx, data)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	y.logger.Info("save playlist success", data.Id)
+
+	return
+}
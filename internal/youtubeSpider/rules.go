@@ -0,0 +1,124 @@
+package youtubeSpider
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/config"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+// noRules the rules of a config built without rules, every channel is kept
+var noRules = &config.Rules{}
+
+// withRules run a job with rules of its own, the configured rules are kept if rules is nil
+func withRules(ctx context.Context, rules *config.Rules) context.Context {
+	if rules == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKeyRules, rules)
+}
+
+// rulesOf the rules of the job of ctx
+func (y *YoutubeSpider) rulesOf(ctx context.Context) *config.Rules {
+	if rules, ok := ctx.Value(ctxKeyRules).(*config.Rules); ok {
+		return rules
+	}
+	return y.rules
+}
+
+// since the beginning of the recency window of rules, the zero time if there is no window
+func since(rules *config.Rules) time.Time {
+	if rules.Recency <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(-rules.Recency)
+}
+
+// rulesHash a hash of rules, an evaluation under other rules is not reused
+func rulesHash(rules *config.Rules) string {
+	bs, _ := json.Marshal(rules)
+	sum := sha1.Sum(bs)
+	return hex.EncodeToString(sum[:])
+}
+
+func inBounds(n int, min int, max int) bool {
+	return (min <= 0 || n >= min) && (max <= 0 || n <= max)
+}
+
+// viewAvgInBounds if the view average of the videos, shorts or streams of a channel is within rules
+func viewAvgInBounds(rules *config.Rules, data *Data) bool {
+	for _, v := range []int{data.ViewAvg10, data.ShortsViewAvg10, data.StreamViewAvg10} {
+		if v > 0 && inBounds(v, rules.MinViewAvg, rules.MaxViewAvg) {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifyStats why the numbers of a channel do not meet rules, empty if they do
+func qualifyStats(rules *config.Rules, data *Data) string {
+	if !inBounds(data.Followers, rules.MinFollowers, rules.MaxFollowers) {
+		return fmt.Sprintf("followers %d", data.Followers)
+	}
+	if !viewAvgInBounds(rules, data) {
+		return fmt.Sprintf("view avg %d, shorts %d, streams %d", data.ViewAvg10, data.ShortsViewAvg10, data.StreamViewAvg10)
+	}
+	return ""
+}
+
+// qualifyProfile why the profile of a channel does not meet rules, empty if it does.
+// The About tab and the language are needed for it
+func qualifyProfile(rules *config.Rules, data *Data) string {
+	if rules.RequireEmail && data.Email == "" {
+		return "no email"
+	}
+	if len(rules.Countries) > 0 && !containsFold(rules.Countries, data.Country) {
+		return fmt.Sprintf("country %q", data.Country)
+	}
+	if len(rules.Languages) > 0 && !containsFold(rules.Languages, data.Language) {
+		return fmt.Sprintf("language %q", data.Language)
+	}
+	return ""
+}
+
+func containsFold(list []string, s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// addLanguage set the spoken language of a channel, as the automatic captions of one of its videos detect it.
+// Only the rules of a job with languages need it, so data is incomplete if the captions can not be fetched.
+// A channel without captions has no language. ctx being done is returned, the player failing is not
+func (y *YoutubeSpider) addLanguage(ctx context.Context, rules *config.Rules, videoId string, data *Data) (err error) {
+	if len(rules.Languages) == 0 || videoId == "" {
+		return
+	}
+
+	respPlayer, e := y.player(ctx, videoId)
+	if e != nil {
+		y.logger.Error(e)
+		data.incomplete = true
+		err = ctx.Err()
+		return
+	}
+
+	for _, v := range respPlayer.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks {
+		if v.Kind == "asr" {
+			data.Language = v.LanguageCode
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,136 @@
+package youtubeSpider
+
+import (
+	"github.com/lizongying/go-youtube/internal/pool"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+const (
+	ResultVideo    = "video"
+	ResultShort    = "short"
+	ResultChannel  = "channel"
+	ResultPlaylist = "playlist"
+	ResultRadio    = "radio"
+)
+
+// channel the id, handle and name of the channel of a byline
+func (b *Byline) channel() (id string, handle string, name string) {
+	if len(b.Runs) < 1 {
+		return
+	}
+	endpoint := b.Runs[0].NavigationEndpoint.BrowseEndpoint
+	id = endpoint.BrowseID
+	handle = strings.TrimPrefix(endpoint.CanonicalBaseURL, "/@")
+	name = b.Runs[0].Text
+	return
+}
+
+// Results the results of a results page or of a continuation of it, shelves flattened,
+// and the token of the next page
+func (r *RespSearchResults) Results() (results []SearchResult, token string) {
+	sections := r.Contents.TwoColumnSearchResultsRenderer.PrimaryContents.SectionListRenderer.Contents
+	for _, v := range r.OnResponseReceivedCommands {
+		sections = append(sections, v.AppendContinuationItemsAction.ContinuationItems...)
+	}
+
+	for _, v := range sections {
+		continuationCommand := v.ContinuationItemRenderer.ContinuationEndpoint.ContinuationCommand
+		if continuationCommand.Request == "CONTINUATION_REQUEST_TYPE_SEARCH" {
+			token = continuationCommand.Token
+			continue
+		}
+		results = appendResults(results, v.ItemSectionRenderer.Contents, "")
+	}
+	return
+}
+
+func appendResults(results []SearchResult, items []SearchItem, shelf string) []SearchResult {
+	for _, v := range items {
+		switch {
+		case v.VideoRenderer.VideoID != "":
+			var title strings.Builder
+			for _, v1 := range v.VideoRenderer.Title.Runs {
+				title.WriteString(v1.Text)
+			}
+			result := SearchResult{
+				Kind:    ResultVideo,
+				VideoId: v.VideoRenderer.VideoID,
+				Title:   title.String(),
+				Shelf:   shelf,
+			}
+			result.ChannelId, result.ChannelHandle, result.ChannelName = v.VideoRenderer.OwnerText.channel()
+			results = append(results, result)
+		case v.ChannelRenderer.ChannelID != "":
+			results = append(results, SearchResult{
+				Kind:          ResultChannel,
+				ChannelId:     v.ChannelRenderer.ChannelID,
+				ChannelHandle: strings.TrimPrefix(v.ChannelRenderer.NavigationEndpoint.BrowseEndpoint.CanonicalBaseURL, "/@"),
+				ChannelName:   v.ChannelRenderer.Title.SimpleText,
+				Title:         v.ChannelRenderer.Title.SimpleText,
+				Shelf:         shelf,
+			})
+		case v.PlaylistRenderer.PlaylistID != "":
+			result := SearchResult{
+				Kind:       ResultPlaylist,
+				PlaylistId: v.PlaylistRenderer.PlaylistID,
+				Title:      v.PlaylistRenderer.Title.SimpleText,
+				Shelf:      shelf,
+			}
+			result.ChannelId, result.ChannelHandle, result.ChannelName = v.PlaylistRenderer.ShortBylineText.channel()
+			results = append(results, result)
+		case v.RadioRenderer.PlaylistID != "":
+			results = append(results, SearchResult{
+				Kind:       ResultRadio,
+				PlaylistId: v.RadioRenderer.PlaylistID,
+				Title:      v.RadioRenderer.Title.SimpleText,
+				Shelf:      shelf,
+			})
+		case len(v.ShelfRenderer.Content.VerticalListRenderer.Items) > 0:
+			results = appendResults(results, v.ShelfRenderer.Content.VerticalListRenderer.Items, v.ShelfRenderer.Title.SimpleText)
+		case len(v.ReelShelfRenderer.Items) > 0:
+			for _, v1 := range v.ReelShelfRenderer.Items {
+				if v1.ReelItemRenderer.VideoID == "" {
+					continue
+				}
+				results = append(results, SearchResult{
+					Kind:    ResultShort,
+					VideoId: v1.ReelItemRenderer.VideoID,
+					Title:   v1.ReelItemRenderer.Headline.SimpleText,
+					Shelf:   v.ReelShelfRenderer.Title.SimpleText,
+				})
+			}
+		}
+	}
+	return results
+}
+
+// submitResults queue the evaluation of the channels the results lead to
+func (y *YoutubeSpider) submitResults(ctx context.Context, p *pool.Pool, keyword string, results []SearchResult) (err error) {
+	for _, v := range results {
+		switch v.Kind {
+		case ResultVideo, ResultChannel:
+			if v.ChannelId == "" {
+				y.logger.Error("runs err")
+				continue
+			}
+			err = y.submitVideos(ctx, p, MetaUser{
+				KeyWord:  keyword,
+				Id:       v.ChannelHandle,
+				Key:      v.ChannelId,
+				UserName: v.ChannelName,
+			})
+		case ResultShort:
+			err = y.submitReel(ctx, p, keyword, v.VideoId)
+		case ResultPlaylist:
+			err = y.submitPlaylist(ctx, p, keyword, v.PlaylistId)
+		default:
+			// mixes are made for the viewer, no channel is behind them
+			continue
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
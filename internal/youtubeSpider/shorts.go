@@ -0,0 +1,66 @@
+package youtubeSpider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/pool"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+// player the player response of a video
+func (y *YoutubeSpider) player(ctx context.Context, videoId string) (respPlayer *RespPlayer, err error) {
+	resp, err := y.innertube.Player(ctx, videoId)
+	if err != nil {
+		return
+	}
+	respPlayer = new(RespPlayer)
+	err = resp.Decode(respPlayer)
+	return
+}
+
+// shorts the Shorts tab of a channel, newest first
+func (y *YoutubeSpider) shorts(ctx context.Context, meta MetaUser) (shorts []upload, err error) {
+	body, err := y.innertube.Get(ctx, fmt.Sprintf(y.urlShorts, meta.Id))
+	if err != nil {
+		return
+	}
+	r := y.initialDataRe.FindSubmatch(body)
+	if len(r) != 2 {
+		err = errors.New("not find content")
+		return
+	}
+
+	shorts, err = y.uploads(ctx, r[1])
+	return
+}
+
+// submitReel queue the evaluation of the channel of a short.
+// Reel items of search results do not carry their channel, the player of the short does
+func (y *YoutubeSpider) submitReel(ctx context.Context, p *pool.Pool, keyword string, videoId string) (err error) {
+	err = p.Submit(ctx, videoId, func(ctx context.Context) (err error) {
+		respPlayer, err := y.player(ctx, videoId)
+		if err != nil {
+			return
+		}
+
+		microformat := respPlayer.Microformat.PlayerMicroformatRenderer
+		_, handle, ok := strings.Cut(microformat.OwnerProfileURL, "/@")
+		if !ok {
+			err = fmt.Errorf("no handle of the channel of %s", videoId)
+			return
+		}
+		meta := MetaUser{
+			KeyWord:  keyword,
+			Id:       handle,
+			Key:      microformat.ExternalChannelID,
+			UserName: microformat.OwnerChannelName,
+		}
+		if y.skip(ctx, meta) {
+			return
+		}
+		err = y.evaluate(ctx, meta)
+		return
+	})
+	return
+}
@@ -0,0 +1,40 @@
+package youtubeSpider
+
+import (
+	"github.com/lizongying/go-youtube/internal/utils"
+	"golang.org/x/net/context"
+	"time"
+)
+
+// saveSnapshot append the metrics of an evaluation to the history of the channel
+func (y *YoutubeSpider) saveSnapshot(ctx context.Context, snapshot *Snapshot) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, y.timeout)
+	defer cancel()
+
+	err = y.store.SaveSnapshot(ctx, snapshot)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	y.logger.Debug("save snapshot success", utils.JsonStr(snapshot))
+
+	return
+}
+
+// Growth the snapshots of a channel since a time, oldest first
+func (y *YoutubeSpider) Growth(ctx context.Context, channelId string, since time.Time) (snapshots []*Snapshot, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, y.timeout)
+	defer cancel()
+
+	snapshots, err = y.store.Snapshots(ctx, channelId, since)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	return
+}
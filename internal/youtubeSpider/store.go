@@ -0,0 +1,185 @@
+package youtubeSpider
+
+import (
+	"errors"
+	"github.com/lizongying/go-youtube/internal/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/net/context"
+	"sync"
+	"time"
+)
+
+// Store where the spider keeps what it crawls
+type Store interface {
+	// UpsertUser set the fields of a channel, setOnInsert only when it is new, and add keywords to its keywords
+	UpsertUser(ctx context.Context, id string, set bson.M, setOnInsert bson.M, keywords []string) error
+	// AddKeyword add a keyword to the keywords of a channel, if it was saved
+	AddKeyword(ctx context.Context, id string, keyword string) error
+	SaveVideo(ctx context.Context, data *VideoData) error
+	// SaveComments the number of comments inserted or changed is returned
+	SaveComments(ctx context.Context, comments []*CommentData) (n int64, err error)
+	SavePlaylist(ctx context.Context, data *PlaylistData) error
+	// Evaluated when a channel was last evaluated, if after a time, nil if it was not
+	Evaluated(ctx context.Context, key string, after time.Time) (evaluated *Evaluated, err error)
+	SaveEvaluated(ctx context.Context, evaluated *Evaluated) error
+	SaveSnapshot(ctx context.Context, snapshot *Snapshot) error
+	// Snapshots the snapshots of a channel since a time, oldest first
+	Snapshots(ctx context.Context, channelId string, since time.Time) (snapshots []*Snapshot, err error)
+}
+
+// MongoStore a Store of one collection per kind in a mongo database
+type MongoStore struct {
+	logger                     *logger.Logger
+	collectionYoutubeUser      *mongo.Collection
+	collectionYoutubeVideo     *mongo.Collection
+	collectionYoutubeComment   *mongo.Collection
+	collectionYoutubePlaylist  *mongo.Collection
+	collectionYoutubeEvaluated *mongo.Collection
+	collectionYoutubeSnapshot  *mongo.Collection
+	snapshotsOnce              sync.Once
+}
+
+// foldKeywordsTimeout the time the channels saved before they had keywords may take to migrate
+const foldKeywordsTimeout = time.Minute * 5
+
+// foldKeywords move the keyword of the channels saved before they had keywords into their keywords
+func (s *MongoStore) foldKeywords(ctx context.Context) (err error) {
+	_, err = s.collectionYoutubeUser.UpdateMany(ctx, bson.M{"keyword": bson.M{"$type": "string"}}, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"keywords": bson.M{"$setUnion": bson.A{
+			bson.M{"$ifNull": bson.A{"$keywords", bson.A{}}},
+			bson.A{"$keyword"},
+		}}}}},
+		{{Key: "$unset", Value: "keyword"}},
+	})
+	return
+}
+
+func (s *MongoStore) UpsertUser(ctx context.Context, id string, set bson.M, setOnInsert bson.M, keywords []string) (err error) {
+	update := bson.M{
+		"$set":         set,
+		"$setOnInsert": setOnInsert,
+	}
+	if len(keywords) > 0 {
+		update["$addToSet"] = bson.M{"keywords": bson.M{"$each": keywords}}
+	}
+
+	_, err = s.collectionYoutubeUser.UpdateOne(ctx, bson.M{"_id": id}, update, options.Update().SetUpsert(true))
+	return
+}
+
+func (s *MongoStore) AddKeyword(ctx context.Context, id string, keyword string) (err error) {
+	_, err = s.collectionYoutubeUser.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+		"$addToSet": bson.M{"keywords": keyword},
+	})
+	return
+}
+
+func (s *MongoStore) SaveVideo(ctx context.Context, data *VideoData) (err error) {
+	_, err = s.collectionYoutubeVideo.ReplaceOne(ctx, bson.M{"_id": data.Id}, data, options.Replace().SetUpsert(true))
+	return
+}
+
+func (s *MongoStore) SaveComments(ctx context.Context, comments []*CommentData) (n int64, err error) {
+	var models []mongo.WriteModel
+	for _, v := range comments {
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": v.Id}).SetReplacement(v).SetUpsert(true))
+	}
+
+	res, err := s.collectionYoutubeComment.BulkWrite(ctx, models)
+	if err != nil {
+		return
+	}
+	n = res.UpsertedCount + res.ModifiedCount
+	return
+}
+
+func (s *MongoStore) SavePlaylist(ctx context.Context, data *PlaylistData) (err error) {
+	_, err = s.collectionYoutubePlaylist.ReplaceOne(ctx, bson.M{"_id": data.Id}, data, options.Replace().SetUpsert(true))
+	return
+}
+
+func (s *MongoStore) Evaluated(ctx context.Context, key string, after time.Time) (evaluated *Evaluated, err error) {
+	evaluated = new(Evaluated)
+	err = s.collectionYoutubeEvaluated.FindOne(ctx, bson.M{
+		"_id":          key,
+		"evaluated_at": bson.M{"$gt": after},
+	}).Decode(evaluated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		evaluated = nil
+		err = nil
+		return
+	}
+	if err != nil {
+		evaluated = nil
+	}
+	return
+}
+
+func (s *MongoStore) SaveEvaluated(ctx context.Context, evaluated *Evaluated) (err error) {
+	_, err = s.collectionYoutubeEvaluated.ReplaceOne(ctx, bson.M{"_id": evaluated.Id}, evaluated, options.Replace().SetUpsert(true))
+	return
+}
+
+// createSnapshots create the snapshots as a time-series collection, bucketed by channel.
+// Snapshots still go to a plain collection where time-series collections are not supported
+func (s *MongoStore) createSnapshots(ctx context.Context) {
+	err := s.collectionYoutubeSnapshot.Database().CreateCollection(ctx, s.collectionYoutubeSnapshot.Name(), options.CreateCollection().
+		SetTimeSeriesOptions(options.TimeSeries().
+			SetTimeField("crawled_at").
+			SetMetaField("channel_id").
+			SetGranularity("hours")))
+	var commandError mongo.CommandError
+	// NamespaceExists
+	if errors.As(err, &commandError) && commandError.HasErrorCode(48) {
+		return
+	}
+	if err != nil {
+		s.logger.Warning(err)
+	}
+}
+
+func (s *MongoStore) SaveSnapshot(ctx context.Context, snapshot *Snapshot) (err error) {
+	s.snapshotsOnce.Do(func() {
+		s.createSnapshots(ctx)
+	})
+
+	_, err = s.collectionYoutubeSnapshot.InsertOne(ctx, snapshot)
+	return
+}
+
+func (s *MongoStore) Snapshots(ctx context.Context, channelId string, since time.Time) (snapshots []*Snapshot, err error) {
+	cursor, err := s.collectionYoutubeSnapshot.Find(ctx, bson.M{
+		"channel_id": channelId,
+		"crawled_at": bson.M{"$gte": since},
+	}, options.Find().SetSort(bson.D{{Key: "crawled_at", Value: 1}}))
+	if err != nil {
+		return
+	}
+
+	err = cursor.All(ctx, &snapshots)
+	return
+}
+
+func NewMongoStore(logger *logger.Logger, mongoDb *mongo.Database) (store *MongoStore, err error) {
+	store = &MongoStore{
+		logger:                     logger,
+		collectionYoutubeUser:      mongoDb.Collection("youtube_user"),
+		collectionYoutubeVideo:     mongoDb.Collection("youtube_video"),
+		collectionYoutubeComment:   mongoDb.Collection("youtube_comment"),
+		collectionYoutubePlaylist:  mongoDb.Collection("youtube_playlist"),
+		collectionYoutubeEvaluated: mongoDb.Collection("youtube_evaluated"),
+		collectionYoutubeSnapshot:  mongoDb.Collection("youtube_snapshot"),
+	}
+
+	// channels saved before they had keywords are migrated once, before the spider saves any
+	ctx, cancel := context.WithTimeout(context.Background(), foldKeywordsTimeout)
+	defer cancel()
+	err = store.foldKeywords(ctx)
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+	return
+}
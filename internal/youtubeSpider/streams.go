@@ -0,0 +1,122 @@
+package youtubeSpider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/proxyPool"
+	"github.com/lizongying/go-youtube/internal/utils"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Streams list the upcoming, live and past streams on the first page of the Live tab of a channel.
+// Like the uploads of the Videos tab they are not saved, they only count to the activity and the stats of the channel.
+// The listing has no description, tags or likes, a Stream saved to youtube_video would replace
+// what Video saved of it, so the details of a stream are saved by Video like those of any video
+func (y *YoutubeSpider) Streams(ctx context.Context, meta MetaUser) (streams []*Stream, err error) {
+	y.logger.Info("Streams", utils.JsonStr(meta))
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = proxyPool.WithKey(ctx, meta.Id)
+
+	body, err := y.innertube.Get(ctx, fmt.Sprintf(y.urlStreams, meta.Id))
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	r := y.initialDataRe.FindSubmatch(body)
+	if len(r) != 2 {
+		err = errors.New("not find content")
+		y.logger.Error(err)
+		return
+	}
+	var respGrid RespGrid
+	err = json.Unmarshal(r[1], &respGrid)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	for _, v := range respGrid.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		if !v.TabRenderer.Selected {
+			continue
+		}
+		for _, v1 := range v.TabRenderer.Content.RichGridRenderer.Contents {
+			video := v1.RichItemRenderer.Content.VideoRenderer
+			if video.VideoID == "" {
+				continue
+			}
+
+			var title strings.Builder
+			for _, v2 := range video.Title.Runs {
+				title.WriteString(v2.Text)
+			}
+			stream := &Stream{
+				Id:        video.VideoID,
+				ChannelId: meta.Key,
+				Title:     title.String(),
+				Live:      LiveWas,
+			}
+
+			for _, v2 := range video.Badges {
+				if v2.MetadataBadgeRenderer.Style == "BADGE_STYLE_TYPE_LIVE_NOW" {
+					stream.Live = LiveNow
+				}
+			}
+			if video.UpcomingEventData.StartTime != "" {
+				stream.Live = LiveUpcoming
+				startTime, e := strconv.ParseInt(video.UpcomingEventData.StartTime, 10, 64)
+				if e != nil {
+					y.logger.Error(e, "startTime", video.UpcomingEventData.StartTime)
+				} else {
+					stream.ScheduledStart = time.Unix(startTime, 0)
+				}
+			}
+
+			// "12,345 views" of a replay, "1,234 watching" of a live stream, "56 waiting" of an upcoming one
+			viewCountText := video.ViewCountText.SimpleText
+			if viewCountText == "" {
+				var runs strings.Builder
+				for _, v2 := range video.ViewCountText.Runs {
+					runs.WriteString(v2.Text)
+				}
+				viewCountText = runs.String()
+			}
+			count, _ := y.parseCount(viewCountText)
+
+			switch stream.Live {
+			case LiveNow:
+				stream.ConcurrentViewers = count
+			case LiveWas:
+				stream.ViewCount = count
+				// like "Streamed 2 days ago"
+				published, _ := y.published(video.PublishedTimeText.SimpleText)
+				stream.PublishedAt = published.Time
+				stream.PublishedUncertainty = published.Uncertainty
+			}
+			streams = append(streams, stream)
+		}
+	}
+
+	return
+}
+
+// streamActive if a channel is live, has a stream scheduled, or streamed since begin
+func streamActive(streams []*Stream, begin time.Time) bool {
+	for _, v := range streams {
+		switch v.Live {
+		case LiveNow, LiveUpcoming:
+			return true
+		case LiveWas:
+			if v.PublishedAt.Add(v.PublishedUncertainty).After(begin) {
+				return true
+			}
+		}
+	}
+	return false
+}
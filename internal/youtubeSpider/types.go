@@ -1,5 +1,11 @@
 package youtubeSpider
 
+import (
+	"github.com/lizongying/go-youtube/internal/config"
+	"github.com/lizongying/go-youtube/internal/innertube"
+	"time"
+)
+
 type RespSearch struct {
 	Contents struct {
 		TwoColumnSearchResultsRenderer struct {
@@ -3945,11 +3951,614 @@ type RespVideos struct {
 	} `json:"metadata"`
 }
 
+type RespPlayer struct {
+	PlayabilityStatus struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	} `json:"playabilityStatus"`
+	VideoDetails struct {
+		VideoID          string   `json:"videoId"`
+		Title            string   `json:"title"`
+		LengthSeconds    string   `json:"lengthSeconds"`
+		Keywords         []string `json:"keywords"`
+		ChannelID        string   `json:"channelId"`
+		ShortDescription string   `json:"shortDescription"`
+		ViewCount        string   `json:"viewCount"`
+		Author           string   `json:"author"`
+		IsLiveContent    bool     `json:"isLiveContent"`
+		IsLive           bool     `json:"isLive"`
+		IsUpcoming       bool     `json:"isUpcoming"`
+	} `json:"videoDetails"`
+	Microformat struct {
+		PlayerMicroformatRenderer struct {
+			Title struct {
+				SimpleText string `json:"simpleText"`
+			} `json:"title"`
+			Description struct {
+				SimpleText string `json:"simpleText"`
+			} `json:"description"`
+			LengthSeconds        string `json:"lengthSeconds"`
+			OwnerProfileURL      string `json:"ownerProfileUrl"`
+			ExternalChannelID    string `json:"externalChannelId"`
+			IsFamilySafe         bool   `json:"isFamilySafe"`
+			IsUnlisted           bool   `json:"isUnlisted"`
+			ViewCount            string `json:"viewCount"`
+			Category             string `json:"category"`
+			PublishDate          string `json:"publishDate"`
+			OwnerChannelName     string `json:"ownerChannelName"`
+			UploadDate           string `json:"uploadDate"`
+			LiveBroadcastDetails *struct {
+				IsLiveNow      bool   `json:"isLiveNow"`
+				StartTimestamp string `json:"startTimestamp"`
+				EndTimestamp   string `json:"endTimestamp"`
+			} `json:"liveBroadcastDetails"`
+		} `json:"playerMicroformatRenderer"`
+	} `json:"microformat"`
+	Captions struct {
+		PlayerCaptionsTracklistRenderer struct {
+			CaptionTracks []struct {
+				LanguageCode string `json:"languageCode"`
+				Kind         string `json:"kind"`
+			} `json:"captionTracks"`
+		} `json:"playerCaptionsTracklistRenderer"`
+	} `json:"captions"`
+}
+
+type RespNext struct {
+	Contents struct {
+		TwoColumnWatchNextResults struct {
+			Results struct {
+				Results struct {
+					Contents []struct {
+						VideoPrimaryInfoRenderer struct {
+							VideoActions struct {
+								MenuRenderer struct {
+									TopLevelButtons []struct {
+										SegmentedLikeDislikeButtonRenderer struct {
+											LikeButton struct {
+												ToggleButtonRenderer struct {
+													DefaultText struct {
+														Accessibility struct {
+															AccessibilityData struct {
+																Label string `json:"label"`
+															} `json:"accessibilityData"`
+														} `json:"accessibility"`
+														SimpleText string `json:"simpleText"`
+													} `json:"defaultText"`
+												} `json:"toggleButtonRenderer"`
+											} `json:"likeButton"`
+										} `json:"segmentedLikeDislikeButtonRenderer"`
+									} `json:"topLevelButtons"`
+								} `json:"menuRenderer"`
+							} `json:"videoActions"`
+							DateText struct {
+								SimpleText string `json:"simpleText"`
+							} `json:"dateText"`
+						} `json:"videoPrimaryInfoRenderer"`
+						ItemSectionRenderer struct {
+							Contents []struct {
+								CommentsEntryPointHeaderRenderer struct {
+									CommentCount struct {
+										SimpleText string `json:"simpleText"`
+									} `json:"commentCount"`
+								} `json:"commentsEntryPointHeaderRenderer"`
+								ContinuationItemRenderer struct {
+									ContinuationEndpoint struct {
+										ContinuationCommand struct {
+											Token   string `json:"token"`
+											Request string `json:"request"`
+										} `json:"continuationCommand"`
+									} `json:"continuationEndpoint"`
+								} `json:"continuationItemRenderer"`
+							} `json:"contents"`
+							SectionIdentifier string `json:"sectionIdentifier"`
+						} `json:"itemSectionRenderer"`
+					} `json:"contents"`
+				} `json:"results"`
+			} `json:"results"`
+		} `json:"twoColumnWatchNextResults"`
+	} `json:"contents"`
+	EngagementPanels []struct {
+		EngagementPanelSectionListRenderer struct {
+			PanelIdentifier string `json:"panelIdentifier"`
+			Header          struct {
+				EngagementPanelTitleHeaderRenderer struct {
+					ContextualInfo struct {
+						Runs []struct {
+							Text string `json:"text"`
+						} `json:"runs"`
+					} `json:"contextualInfo"`
+				} `json:"engagementPanelTitleHeaderRenderer"`
+			} `json:"header"`
+		} `json:"engagementPanelSectionListRenderer"`
+	} `json:"engagementPanels"`
+}
+
+type RespComments struct {
+	OnResponseReceivedEndpoints []struct {
+		ReloadContinuationItemsCommand struct {
+			TargetID          string        `json:"targetId"`
+			ContinuationItems []CommentItem `json:"continuationItems"`
+		} `json:"reloadContinuationItemsCommand"`
+		AppendContinuationItemsAction struct {
+			ContinuationItems []CommentItem `json:"continuationItems"`
+		} `json:"appendContinuationItemsAction"`
+	} `json:"onResponseReceivedEndpoints"`
+}
+
+type CommentItem struct {
+	CommentThreadRenderer struct {
+		Comment struct {
+			CommentRenderer CommentRenderer `json:"commentRenderer"`
+		} `json:"comment"`
+		Replies struct {
+			CommentRepliesRenderer struct {
+				Contents []CommentItem `json:"contents"`
+			} `json:"commentRepliesRenderer"`
+		} `json:"replies"`
+	} `json:"commentThreadRenderer"`
+	CommentRenderer          CommentRenderer `json:"commentRenderer"`
+	ContinuationItemRenderer struct {
+		ContinuationEndpoint struct {
+			ContinuationCommand struct {
+				Token   string `json:"token"`
+				Request string `json:"request"`
+			} `json:"continuationCommand"`
+		} `json:"continuationEndpoint"`
+		Button struct {
+			ButtonRenderer struct {
+				Command struct {
+					ContinuationCommand struct {
+						Token   string `json:"token"`
+						Request string `json:"request"`
+					} `json:"continuationCommand"`
+				} `json:"command"`
+			} `json:"buttonRenderer"`
+		} `json:"button"`
+	} `json:"continuationItemRenderer"`
+}
+
+// token the continuation of the item, behind a button for replies
+func (c *CommentItem) token() string {
+	r := c.ContinuationItemRenderer
+	if token := r.ContinuationEndpoint.ContinuationCommand.Token; token != "" {
+		return token
+	}
+	return r.Button.ButtonRenderer.Command.ContinuationCommand.Token
+}
+
+type CommentRenderer struct {
+	CommentID  string `json:"commentId"`
+	AuthorText struct {
+		SimpleText string `json:"simpleText"`
+	} `json:"authorText"`
+	AuthorEndpoint struct {
+		BrowseEndpoint struct {
+			BrowseID         string `json:"browseId"`
+			CanonicalBaseURL string `json:"canonicalBaseUrl"`
+		} `json:"browseEndpoint"`
+	} `json:"authorEndpoint"`
+	ContentText struct {
+		Runs []struct {
+			Text string `json:"text"`
+		} `json:"runs"`
+	} `json:"contentText"`
+	PublishedTimeText struct {
+		Runs []struct {
+			Text string `json:"text"`
+		} `json:"runs"`
+	} `json:"publishedTimeText"`
+	VoteCount struct {
+		SimpleText string `json:"simpleText"`
+	} `json:"voteCount"`
+	ReplyCount int `json:"replyCount"`
+}
+
+type RespAbout struct {
+	Contents struct {
+		TwoColumnBrowseResultsRenderer struct {
+			Tabs []struct {
+				TabRenderer struct {
+					Title   string `json:"title"`
+					Content struct {
+						SectionListRenderer struct {
+							Contents []struct {
+								ItemSectionRenderer struct {
+									Contents []struct {
+										ChannelAboutFullMetadataRenderer struct {
+											ChannelID   string `json:"channelId"`
+											Description struct {
+												SimpleText string `json:"simpleText"`
+											} `json:"description"`
+											PrimaryLinks []struct {
+												NavigationEndpoint struct {
+													URLEndpoint struct {
+														URL string `json:"url"`
+													} `json:"urlEndpoint"`
+												} `json:"navigationEndpoint"`
+												Title struct {
+													SimpleText string `json:"simpleText"`
+												} `json:"title"`
+											} `json:"primaryLinks"`
+											Country struct {
+												SimpleText string `json:"simpleText"`
+											} `json:"country"`
+											JoinedDateText struct {
+												Runs []struct {
+													Text string `json:"text"`
+												} `json:"runs"`
+											} `json:"joinedDateText"`
+											ViewCountText struct {
+												SimpleText string `json:"simpleText"`
+											} `json:"viewCountText"`
+											BusinessEmailLabel struct {
+												SimpleText string `json:"simpleText"`
+											} `json:"businessEmailLabel"`
+											BusinessEmailRevealButton *struct{} `json:"businessEmailRevealButton"`
+										} `json:"channelAboutFullMetadataRenderer"`
+									} `json:"contents"`
+								} `json:"itemSectionRenderer"`
+							} `json:"contents"`
+						} `json:"sectionListRenderer"`
+					} `json:"content"`
+				} `json:"tabRenderer"`
+			} `json:"tabs"`
+		} `json:"twoColumnBrowseResultsRenderer"`
+	} `json:"contents"`
+}
+
+type RespGrid struct {
+	Contents struct {
+		TwoColumnBrowseResultsRenderer struct {
+			Tabs []struct {
+				TabRenderer struct {
+					Title    string `json:"title"`
+					Selected bool   `json:"selected"`
+					Content  struct {
+						RichGridRenderer struct {
+							Contents []GridItem `json:"contents"`
+						} `json:"richGridRenderer"`
+					} `json:"content"`
+				} `json:"tabRenderer"`
+			} `json:"tabs"`
+		} `json:"twoColumnBrowseResultsRenderer"`
+	} `json:"contents"`
+}
+
+type RespGridContinuation struct {
+	OnResponseReceivedActions []struct {
+		AppendContinuationItemsAction struct {
+			ContinuationItems []GridItem `json:"continuationItems"`
+		} `json:"appendContinuationItemsAction"`
+	} `json:"onResponseReceivedActions"`
+}
+
+type GridItem struct {
+	RichItemRenderer struct {
+		Content struct {
+			VideoRenderer struct {
+				VideoID       string `json:"videoId"`
+				ViewCountText struct {
+					SimpleText string `json:"simpleText"`
+					Runs       []struct {
+						Text string `json:"text"`
+					} `json:"runs"`
+				} `json:"viewCountText"`
+				PublishedTimeText struct {
+					SimpleText string `json:"simpleText"`
+				} `json:"publishedTimeText"`
+				Title struct {
+					Runs []struct {
+						Text string `json:"text"`
+					} `json:"runs"`
+				} `json:"title"`
+				UpcomingEventData struct {
+					StartTime string `json:"startTime"`
+				} `json:"upcomingEventData"`
+				Badges []struct {
+					MetadataBadgeRenderer struct {
+						Style string `json:"style"`
+					} `json:"metadataBadgeRenderer"`
+				} `json:"badges"`
+			} `json:"videoRenderer"`
+			ReelItemRenderer struct {
+				VideoID       string `json:"videoId"`
+				ViewCountText struct {
+					SimpleText string `json:"simpleText"`
+				} `json:"viewCountText"`
+			} `json:"reelItemRenderer"`
+			ShortsLockupViewModel struct {
+				OnTap struct {
+					InnertubeCommand struct {
+						ReelWatchEndpoint struct {
+							VideoID string `json:"videoId"`
+						} `json:"reelWatchEndpoint"`
+					} `json:"innertubeCommand"`
+				} `json:"onTap"`
+				OverlayMetadata struct {
+					SecondaryText struct {
+						Content string `json:"content"`
+					} `json:"secondaryText"`
+				} `json:"overlayMetadata"`
+			} `json:"shortsLockupViewModel"`
+		} `json:"content"`
+	} `json:"richItemRenderer"`
+	ContinuationItemRenderer struct {
+		ContinuationEndpoint struct {
+			ContinuationCommand struct {
+				Token string `json:"token"`
+			} `json:"continuationCommand"`
+		} `json:"continuationEndpoint"`
+	} `json:"continuationItemRenderer"`
+}
+
+type RespSearchResults struct {
+	Contents struct {
+		TwoColumnSearchResultsRenderer struct {
+			PrimaryContents struct {
+				SectionListRenderer struct {
+					Contents []SearchSection `json:"contents"`
+				} `json:"sectionListRenderer"`
+			} `json:"primaryContents"`
+		} `json:"twoColumnSearchResultsRenderer"`
+	} `json:"contents"`
+	OnResponseReceivedCommands []struct {
+		AppendContinuationItemsAction struct {
+			ContinuationItems []SearchSection `json:"continuationItems"`
+		} `json:"appendContinuationItemsAction"`
+	} `json:"onResponseReceivedCommands"`
+}
+
+type SearchSection struct {
+	ItemSectionRenderer struct {
+		Contents []SearchItem `json:"contents"`
+	} `json:"itemSectionRenderer"`
+	ContinuationItemRenderer struct {
+		ContinuationEndpoint struct {
+			ContinuationCommand struct {
+				Token   string `json:"token"`
+				Request string `json:"request"`
+			} `json:"continuationCommand"`
+		} `json:"continuationEndpoint"`
+	} `json:"continuationItemRenderer"`
+}
+
+type SearchItem struct {
+	VideoRenderer struct {
+		VideoID string `json:"videoId"`
+		Title   struct {
+			Runs []struct {
+				Text string `json:"text"`
+			} `json:"runs"`
+		} `json:"title"`
+		OwnerText Byline `json:"ownerText"`
+	} `json:"videoRenderer"`
+	ChannelRenderer struct {
+		ChannelID string `json:"channelId"`
+		Title     struct {
+			SimpleText string `json:"simpleText"`
+		} `json:"title"`
+		NavigationEndpoint struct {
+			BrowseEndpoint struct {
+				BrowseID         string `json:"browseId"`
+				CanonicalBaseURL string `json:"canonicalBaseUrl"`
+			} `json:"browseEndpoint"`
+		} `json:"navigationEndpoint"`
+	} `json:"channelRenderer"`
+	PlaylistRenderer PlaylistRenderer `json:"playlistRenderer"`
+	RadioRenderer    struct {
+		PlaylistID string `json:"playlistId"`
+		Title      struct {
+			SimpleText string `json:"simpleText"`
+		} `json:"title"`
+	} `json:"radioRenderer"`
+	ShelfRenderer struct {
+		Title struct {
+			SimpleText string `json:"simpleText"`
+		} `json:"title"`
+		Content struct {
+			VerticalListRenderer struct {
+				Items []SearchItem `json:"items"`
+			} `json:"verticalListRenderer"`
+		} `json:"content"`
+	} `json:"shelfRenderer"`
+	ReelShelfRenderer struct {
+		Title struct {
+			SimpleText string `json:"simpleText"`
+		} `json:"title"`
+		Items []struct {
+			ReelItemRenderer struct {
+				VideoID  string `json:"videoId"`
+				Headline struct {
+					SimpleText string `json:"simpleText"`
+				} `json:"headline"`
+			} `json:"reelItemRenderer"`
+		} `json:"items"`
+	} `json:"reelShelfRenderer"`
+}
+
+// Byline the channel a result belongs to
+type Byline struct {
+	Runs []struct {
+		Text               string `json:"text"`
+		NavigationEndpoint struct {
+			BrowseEndpoint struct {
+				BrowseID         string `json:"browseId"`
+				CanonicalBaseURL string `json:"canonicalBaseUrl"`
+			} `json:"browseEndpoint"`
+		} `json:"navigationEndpoint"`
+	} `json:"runs"`
+}
+
+type PlaylistRenderer struct {
+	PlaylistID string `json:"playlistId"`
+	Title      struct {
+		SimpleText string `json:"simpleText"`
+	} `json:"title"`
+	VideoCount      string `json:"videoCount"`
+	ShortBylineText Byline `json:"shortBylineText"`
+}
+
+type RespPlaylist struct {
+	Header struct {
+		PlaylistHeaderRenderer struct {
+			PlaylistID string `json:"playlistId"`
+			Title      struct {
+				SimpleText string `json:"simpleText"`
+			} `json:"title"`
+			NumVideosText struct {
+				Runs []struct {
+					Text string `json:"text"`
+				} `json:"runs"`
+			} `json:"numVideosText"`
+			OwnerText struct {
+				Runs []struct {
+					Text               string `json:"text"`
+					NavigationEndpoint struct {
+						BrowseEndpoint struct {
+							BrowseID         string `json:"browseId"`
+							CanonicalBaseURL string `json:"canonicalBaseUrl"`
+						} `json:"browseEndpoint"`
+					} `json:"navigationEndpoint"`
+				} `json:"runs"`
+			} `json:"ownerText"`
+		} `json:"playlistHeaderRenderer"`
+	} `json:"header"`
+	Contents struct {
+		TwoColumnBrowseResultsRenderer struct {
+			Tabs []struct {
+				TabRenderer struct {
+					Content struct {
+						SectionListRenderer struct {
+							Contents []struct {
+								ItemSectionRenderer struct {
+									Contents []struct {
+										PlaylistVideoListRenderer struct {
+											Contents []PlaylistItem `json:"contents"`
+										} `json:"playlistVideoListRenderer"`
+									} `json:"contents"`
+								} `json:"itemSectionRenderer"`
+							} `json:"contents"`
+						} `json:"sectionListRenderer"`
+					} `json:"content"`
+				} `json:"tabRenderer"`
+			} `json:"tabs"`
+		} `json:"twoColumnBrowseResultsRenderer"`
+	} `json:"contents"`
+}
+
+type RespPlaylistContinuation struct {
+	OnResponseReceivedActions []struct {
+		AppendContinuationItemsAction struct {
+			ContinuationItems []PlaylistItem `json:"continuationItems"`
+		} `json:"appendContinuationItemsAction"`
+	} `json:"onResponseReceivedActions"`
+}
+
+type PlaylistItem struct {
+	PlaylistVideoRenderer struct {
+		VideoID string `json:"videoId"`
+		Title   struct {
+			Runs []struct {
+				Text string `json:"text"`
+			} `json:"runs"`
+		} `json:"title"`
+		LengthSeconds   string `json:"lengthSeconds"`
+		ShortBylineText struct {
+			Runs []struct {
+				Text               string `json:"text"`
+				NavigationEndpoint struct {
+					BrowseEndpoint struct {
+						BrowseID         string `json:"browseId"`
+						CanonicalBaseURL string `json:"canonicalBaseUrl"`
+					} `json:"browseEndpoint"`
+				} `json:"navigationEndpoint"`
+			} `json:"runs"`
+		} `json:"shortBylineText"`
+	} `json:"playlistVideoRenderer"`
+	ContinuationItemRenderer struct {
+		ContinuationEndpoint struct {
+			ContinuationCommand struct {
+				Token string `json:"token"`
+			} `json:"continuationCommand"`
+		} `json:"continuationEndpoint"`
+	} `json:"continuationItemRenderer"`
+}
+
+type RespPlaylists struct {
+	Contents struct {
+		TwoColumnBrowseResultsRenderer struct {
+			Tabs []struct {
+				TabRenderer struct {
+					Title    string `json:"title"`
+					Selected bool   `json:"selected"`
+					Content  struct {
+						SectionListRenderer struct {
+							Contents []struct {
+								ItemSectionRenderer struct {
+									Contents []struct {
+										GridRenderer struct {
+											Items []struct {
+												GridPlaylistRenderer struct {
+													PlaylistID string `json:"playlistId"`
+													Title      struct {
+														Runs []struct {
+															Text string `json:"text"`
+														} `json:"runs"`
+													} `json:"title"`
+													VideoCountShortText struct {
+														SimpleText string `json:"simpleText"`
+													} `json:"videoCountShortText"`
+												} `json:"gridPlaylistRenderer"`
+												LockupViewModel struct {
+													ContentID   string `json:"contentId"`
+													ContentType string `json:"contentType"`
+													Metadata    struct {
+														LockupMetadataViewModel struct {
+															Title struct {
+																Content string `json:"content"`
+															} `json:"title"`
+														} `json:"lockupMetadataViewModel"`
+													} `json:"metadata"`
+												} `json:"lockupViewModel"`
+											} `json:"items"`
+										} `json:"gridRenderer"`
+									} `json:"contents"`
+								} `json:"itemSectionRenderer"`
+							} `json:"contents"`
+						} `json:"sectionListRenderer"`
+					} `json:"content"`
+				} `json:"tabRenderer"`
+			} `json:"tabs"`
+		} `json:"twoColumnBrowseResultsRenderer"`
+	} `json:"contents"`
+}
+
 type MetaSearch struct {
 	Keyword       string
 	Page          int
 	MaxPage       int
 	NextPageToken string
+	Filter        innertube.SearchFilter
+	Rules         *config.Rules `json:",omitempty"`
+}
+
+type SearchResult struct {
+	Kind          string
+	VideoId       string
+	PlaylistId    string
+	ChannelId     string
+	ChannelHandle string
+	ChannelName   string
+	Title         string
+	// the title of the shelf the result was found in, if any
+	Shelf string
+}
+
+type MetaVideo struct {
+	Id        string
+	ChannelId string
+	MaxPage   int
 }
 
 type MetaUser struct {
@@ -3957,15 +4566,146 @@ type MetaUser struct {
 	Id       string
 	Key      string
 	UserName string
+	Rules    *config.Rules `json:",omitempty"`
 }
 
 type Data struct {
-	Id          string `bson:"_id" json:"id"`
-	UserName    string `bson:"user_name" json:"user_name"`
-	Description string `bson:"description" json:"description"`
-	Link        string `bson:"link" json:"link"`
-	Email       string `bson:"email" json:"email"`
-	Followers   int    `bson:"followers" json:"followers"`
-	ViewAvg10   int    `bson:"view_avg10" json:"view_avg10"`
-	Keyword     string `bson:"keyword" json:"keyword"`
+	Id               string      `bson:"_id" json:"id"`
+	UserName         string      `bson:"user_name" json:"user_name"`
+	Description      string      `bson:"description" json:"description"`
+	Link             string      `bson:"link" json:"link"`
+	Email            string      `bson:"email" json:"email"`
+	Emails           []string    `bson:"emails" json:"emails"`
+	Socials          []Social    `bson:"socials" json:"socials"`
+	Followers        int         `bson:"followers" json:"followers"`
+	ViewAvg10        int         `bson:"view_avg10" json:"view_avg10"`
+	Keyword          string      `bson:"-" json:"keyword"`
+	Keywords         []string    `bson:"keywords" json:"keywords"`
+	FirstSeen        time.Time   `bson:"first_seen" json:"first_seen"`
+	LastSeen         time.Time   `bson:"last_seen" json:"last_seen"`
+	Links            []AboutLink `bson:"links" json:"links"`
+	Country          string      `bson:"country" json:"country"`
+	JoinedAt         time.Time   `bson:"joined_at,omitempty" json:"joined_at"`
+	TotalViews       int         `bson:"total_views,omitempty" json:"total_views"`
+	HasBusinessEmail bool        `bson:"has_business_email" json:"has_business_email"`
+	VideoCount       int         `bson:"video_count,omitempty" json:"video_count"`
+	ViewAvg          int         `bson:"view_avg" json:"view_avg"`
+	UploadsPerMonth  float64     `bson:"uploads_per_month" json:"uploads_per_month"`
+	ShortsCount      int         `bson:"shorts_count" json:"shorts_count"`
+	ShortsViewAvg10  int         `bson:"shorts_view_avg10" json:"shorts_view_avg10"`
+	StreamCount      int         `bson:"stream_count" json:"stream_count"`
+	StreamViewAvg10  int         `bson:"stream_view_avg10" json:"stream_view_avg10"`
+	LiveNow          bool        `bson:"live_now" json:"live_now"`
+	Language         string      `bson:"language,omitempty" json:"language"`
+
+	aboutFetched bool
+	tabsFetched  bool
+	// incomplete if a fetch the rules needed failed, the channel is to be evaluated again
+	incomplete bool
+}
+
+// Social a profile of a channel on another platform, or its own site
+type Social struct {
+	Platform string `bson:"platform" json:"platform"`
+	Handle   string `bson:"handle" json:"handle"`
+	Url      string `bson:"url" json:"url"`
+}
+
+type AboutLink struct {
+	Title string `bson:"title" json:"title"`
+	Url   string `bson:"url" json:"url"`
+}
+
+type About struct {
+	Description      string
+	Links            []AboutLink
+	Country          string
+	JoinedAt         time.Time
+	TotalViews       int
+	HasBusinessEmail bool
+}
+
+type VideoData struct {
+	Id           string    `bson:"_id" json:"id"`
+	ChannelId    string    `bson:"channel_id" json:"channel_id"`
+	Title        string    `bson:"title" json:"title"`
+	Description  string    `bson:"description" json:"description"`
+	PublishedAt  time.Time `bson:"published_at" json:"published_at"`
+	Duration     int       `bson:"duration" json:"duration"`
+	Tags         []string  `bson:"tags" json:"tags"`
+	Category     string    `bson:"category" json:"category"`
+	ViewCount    int       `bson:"view_count" json:"view_count"`
+	LikeCount    int       `bson:"like_count" json:"like_count"`
+	CommentCount int       `bson:"comment_count" json:"comment_count"`
+	Live         string    `bson:"live" json:"live"`
+	Premiere     bool      `bson:"premiere" json:"premiere"`
+	FamilySafe   bool      `bson:"family_safe" json:"family_safe"`
+	CrawledAt    time.Time `bson:"crawled_at" json:"crawled_at"`
+}
+
+type CommentData struct {
+	Id                   string        `bson:"_id" json:"id"`
+	VideoId              string        `bson:"video_id" json:"video_id"`
+	ChannelId            string        `bson:"channel_id" json:"channel_id"`
+	ParentId             string        `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	AuthorId             string        `bson:"author_id" json:"author_id"`
+	AuthorHandle         string        `bson:"author_handle" json:"author_handle"`
+	AuthorName           string        `bson:"author_name" json:"author_name"`
+	Text                 string        `bson:"text" json:"text"`
+	LikeCount            int           `bson:"like_count" json:"like_count"`
+	ReplyCount           int           `bson:"reply_count" json:"reply_count"`
+	PublishedTime        string        `bson:"published_time" json:"published_time"`
+	PublishedAt          time.Time     `bson:"published_at" json:"published_at"`
+	PublishedUncertainty time.Duration `bson:"published_uncertainty" json:"published_uncertainty"`
+	CrawledAt            time.Time     `bson:"crawled_at" json:"crawled_at"`
+}
+
+// Stream a stream as the Live tab lists it, it is not saved
+type Stream struct {
+	Id                   string        `bson:"_id" json:"id"`
+	ChannelId            string        `bson:"channel_id" json:"channel_id"`
+	Title                string        `bson:"title" json:"title"`
+	Live                 string        `bson:"live" json:"live"`
+	ScheduledStart       time.Time     `bson:"scheduled_start" json:"scheduled_start"`
+	ConcurrentViewers    int           `bson:"concurrent_viewers" json:"concurrent_viewers"`
+	ViewCount            int           `bson:"view_count" json:"view_count"`
+	PublishedAt          time.Time     `bson:"published_at" json:"published_at"`
+	PublishedUncertainty time.Duration `bson:"published_uncertainty" json:"published_uncertainty"`
+}
+
+type PlaylistData struct {
+	Id            string           `bson:"_id" json:"id"`
+	Title         string           `bson:"title" json:"title"`
+	ChannelId     string           `bson:"channel_id" json:"channel_id"`
+	ChannelHandle string           `bson:"channel_handle" json:"channel_handle"`
+	ChannelName   string           `bson:"channel_name" json:"channel_name"`
+	VideoCount    int              `bson:"video_count" json:"video_count"`
+	Videos        []*PlaylistVideo `bson:"videos" json:"videos"`
+	CrawledAt     time.Time        `bson:"crawled_at" json:"crawled_at"`
+}
+
+type PlaylistVideo struct {
+	Id            string `bson:"id" json:"id"`
+	Title         string `bson:"title" json:"title"`
+	Duration      int    `bson:"duration" json:"duration"`
+	ChannelId     string `bson:"channel_id" json:"channel_id"`
+	ChannelHandle string `bson:"channel_handle" json:"channel_handle"`
+	ChannelName   string `bson:"channel_name" json:"channel_name"`
+}
+
+type Evaluated struct {
+	Id          string    `bson:"_id" json:"id"`
+	EvaluatedAt time.Time `bson:"evaluated_at" json:"evaluated_at"`
+	RulesHash   string    `bson:"rules_hash" json:"rules_hash"`
+}
+
+type Snapshot struct {
+	ChannelId       string    `bson:"channel_id" json:"channel_id"`
+	Handle          string    `bson:"handle" json:"handle"`
+	CrawledAt       time.Time `bson:"crawled_at" json:"crawled_at"`
+	Followers       int       `bson:"followers" json:"followers"`
+	ViewAvg10       int       `bson:"view_avg10" json:"view_avg10"`
+	ShortsViewAvg10 int       `bson:"shorts_view_avg10" json:"shorts_view_avg10"`
+	StreamViewAvg10 int       `bson:"stream_view_avg10" json:"stream_view_avg10"`
+	VideoCount      int       `bson:"video_count" json:"video_count"`
 }
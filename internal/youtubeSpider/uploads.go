@@ -0,0 +1,172 @@
+package youtubeSpider
+
+import (
+	"encoding/json"
+	"github.com/lizongying/go-youtube/internal/innertube"
+	"github.com/lizongying/go-youtube/internal/relativeTime"
+	"golang.org/x/net/context"
+	"time"
+)
+
+// upload a video in the uploads list of a channel
+type upload struct {
+	id        string
+	viewCount int
+	published relativeTime.Estimate
+	// unknown if the published time was not understood, it is zero then
+	unknown bool
+}
+
+// uploads the videos of the grid tab selected in initialData, like Videos or Shorts, newest first.
+// Only the first page of the grid is read, unless the walk is enabled. Then the
+// browse continuations are followed until videosMaxCount videos or a video older than videosMaxAge
+func (y *YoutubeSpider) uploads(ctx context.Context, initialData []byte) (uploads []upload, err error) {
+	var respGrid RespGrid
+	err = json.Unmarshal(initialData, &respGrid)
+	if err != nil {
+		return
+	}
+
+	var items []GridItem
+	for _, v := range respGrid.Contents.TwoColumnBrowseResultsRenderer.Tabs {
+		if v.TabRenderer.Selected {
+			items = v.TabRenderer.Content.RichGridRenderer.Contents
+		}
+	}
+
+	cutoff := time.Time{}
+	if y.videosMaxAge > 0 {
+		cutoff = time.Now().Add(-y.videosMaxAge)
+	}
+	w := new(walk)
+	for page := 1; ; page++ {
+		n := len(uploads)
+		token := ""
+		for _, v := range items {
+			if t := v.ContinuationItemRenderer.ContinuationEndpoint.ContinuationCommand.Token; t != "" {
+				token = t
+				continue
+			}
+
+			content := v.RichItemRenderer.Content
+			u := upload{}
+			viewCountText := ""
+			switch {
+			case content.VideoRenderer.VideoID != "":
+				u.id = content.VideoRenderer.VideoID
+				viewCountText = content.VideoRenderer.ViewCountText.SimpleText
+				published, e := y.published(content.VideoRenderer.PublishedTimeText.SimpleText)
+				u.published = published
+				u.unknown = e != nil
+			case content.ReelItemRenderer.VideoID != "":
+				u.id = content.ReelItemRenderer.VideoID
+				viewCountText = content.ReelItemRenderer.ViewCountText.SimpleText
+			case content.ShortsLockupViewModel.OnTap.InnertubeCommand.ReelWatchEndpoint.VideoID != "":
+				u.id = content.ShortsLockupViewModel.OnTap.InnertubeCommand.ReelWatchEndpoint.VideoID
+				viewCountText = content.ShortsLockupViewModel.OverlayMetadata.SecondaryText.Content
+			default:
+				continue
+			}
+
+			// shorts abbreviate their views, like "1.2M views"
+			if viewCountText != "" {
+				viewCount, e := y.parseCount(viewCountText)
+				if e != nil {
+					y.logger.Error(e, "viewCount", viewCountText)
+					continue
+				}
+				u.viewCount = viewCount
+			}
+
+			// shorts carry no published time
+			if y.videosAll && !u.published.Time.IsZero() && u.published.Latest().Before(cutoff) {
+				y.logger.Info("max age", page)
+				return
+			}
+			uploads = append(uploads, u)
+			if y.videosAll && y.videosMaxCount > 0 && len(uploads) >= y.videosMaxCount {
+				y.logger.Info("max count", page)
+				return
+			}
+		}
+
+		if !y.videosAll || !w.next(token, len(uploads)-n) {
+			return
+		}
+
+		items, err = y.gridItems(ctx, token)
+		if err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+				return
+			}
+			// the pages already read are still good for the stats
+			y.logger.Error(err)
+			err = nil
+			return
+		}
+	}
+}
+
+// gridItems the items appended to a rich grid by a browse continuation
+func (y *YoutubeSpider) gridItems(ctx context.Context, token string) (items []GridItem, err error) {
+	resp, err := y.innertube.Continue(ctx, innertube.Continuation{
+		Endpoint: innertube.EndpointBrowse,
+		Token:    token,
+	})
+	if err != nil {
+		return
+	}
+
+	var respGrid RespGridContinuation
+	err = resp.Decode(&respGrid)
+	if err != nil {
+		return
+	}
+
+	for _, v := range respGrid.OnResponseReceivedActions {
+		items = append(items, v.AppendContinuationItemsAction.ContinuationItems...)
+	}
+
+	return
+}
+
+// uploadStats the average views of all uploads and how many were uploaded per month
+// between the oldest of them and now, counting a shorter span as one month
+func uploadStats(uploads []upload) (viewAvg int, perMonth float64) {
+	if len(uploads) == 0 {
+		return
+	}
+
+	viewTotal := 0
+	for _, v := range uploads {
+		viewTotal += v.viewCount
+	}
+	viewAvg = viewTotal / len(uploads)
+
+	months := 0.0
+	for i := len(uploads) - 1; i >= 0; i-- {
+		if !uploads[i].published.Time.IsZero() {
+			months = time.Since(uploads[i].published.Time).Hours() / 24 / 30
+			break
+		}
+	}
+	if months < 1 {
+		months = 1
+	}
+	perMonth = float64(len(uploads)) / months
+	return
+}
+
+// published the time of a relative published time like "3 weeks ago", zero if there is none.
+// A time not understood is logged and returned as an error, it is zero too
+func (y *YoutubeSpider) published(text string) (estimate relativeTime.Estimate, err error) {
+	if text == "" {
+		return
+	}
+	estimate, err = relativeTime.Parse(text, y.innertube.Hl(), time.Now())
+	if err != nil {
+		y.logger.Error(err, "hl", y.innertube.Hl())
+	}
+	return
+}
@@ -0,0 +1,162 @@
+package youtubeSpider
+
+import (
+	"github.com/lizongying/go-youtube/internal/utils"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LiveNone     = "none"
+	LiveUpcoming = "upcoming"
+	LiveNow      = "live"
+	LiveWas      = "was_live"
+)
+
+// parsePublishDate microformat dates are either a day or a full timestamp
+func parsePublishDate(s string) (t time.Time, err error) {
+	t, err = time.Parse(time.RFC3339, s)
+	if err == nil {
+		return
+	}
+
+	t, err = time.Parse(time.DateOnly, s)
+	return
+}
+
+// Video fetch the details of a video from the player and next endpoints, and save them
+func (y *YoutubeSpider) Video(ctx context.Context, videoId string) (data *VideoData, err error) {
+	y.logger.Info("Video", videoId)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	respPlayer, err := y.player(ctx, videoId)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	resp, err := y.innertube.Next(ctx, videoId)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	var respNext RespNext
+	err = resp.Decode(&respNext)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	details := respPlayer.VideoDetails
+	microformat := respPlayer.Microformat.PlayerMicroformatRenderer
+
+	description := microformat.Description.SimpleText
+	if description == "" {
+		description = details.ShortDescription
+	}
+
+	lengthSeconds := microformat.LengthSeconds
+	if lengthSeconds == "" {
+		lengthSeconds = details.LengthSeconds
+	}
+	duration, _ := strconv.Atoi(lengthSeconds)
+
+	viewCount, _ := strconv.Atoi(details.ViewCount)
+
+	publishedAt, e := parsePublishDate(microformat.PublishDate)
+	if e != nil {
+		y.logger.Error(e, "publishDate", microformat.PublishDate)
+	}
+
+	live := LiveNone
+	premiere := false
+	if broadcast := microformat.LiveBroadcastDetails; broadcast != nil {
+		switch {
+		case broadcast.IsLiveNow:
+			live = LiveNow
+		case broadcast.EndTimestamp != "":
+			live = LiveWas
+		default:
+			live = LiveUpcoming
+		}
+		// premieres are broadcast like streams, but of an uploaded video
+		premiere = !details.IsLiveContent
+	} else if details.IsUpcoming {
+		live = LiveUpcoming
+	}
+
+	likeCount := 0
+	commentCount := 0
+	for _, v := range respNext.Contents.TwoColumnWatchNextResults.Results.Results.Contents {
+		for _, v1 := range v.VideoPrimaryInfoRenderer.VideoActions.MenuRenderer.TopLevelButtons {
+			label := v1.SegmentedLikeDislikeButtonRenderer.LikeButton.ToggleButtonRenderer.DefaultText.Accessibility.AccessibilityData.Label
+			if label == "" {
+				continue
+			}
+			likeCount, _ = strconv.Atoi(strings.Join(y.intRe.FindAllString(label, -1), ""))
+		}
+		for _, v1 := range v.ItemSectionRenderer.Contents {
+			commentCountText := v1.CommentsEntryPointHeaderRenderer.CommentCount.SimpleText
+			if commentCountText != "" {
+				commentCount, _ = y.parseCount(commentCountText)
+			}
+		}
+	}
+	// the exact count of the comments panel wins over the abbreviated one
+	for _, v := range respNext.EngagementPanels {
+		panel := v.EngagementPanelSectionListRenderer
+		if panel.PanelIdentifier != "engagement-panel-comments-section" {
+			continue
+		}
+		runs := panel.Header.EngagementPanelTitleHeaderRenderer.ContextualInfo.Runs
+		if len(runs) > 0 {
+			commentCount, _ = strconv.Atoi(strings.Join(y.intRe.FindAllString(runs[0].Text, -1), ""))
+		}
+	}
+
+	data = &VideoData{
+		Id:           videoId,
+		ChannelId:    details.ChannelID,
+		Title:        details.Title,
+		Description:  description,
+		PublishedAt:  publishedAt,
+		Duration:     duration,
+		Tags:         details.Keywords,
+		Category:     microformat.Category,
+		ViewCount:    viewCount,
+		LikeCount:    likeCount,
+		CommentCount: commentCount,
+		Live:         live,
+		Premiere:     premiere,
+		FamilySafe:   microformat.IsFamilySafe,
+		CrawledAt:    time.Now(),
+	}
+	y.logger.Debug(utils.JsonStr(data))
+
+	err = y.saveVideo(ctx, data)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+
+	return
+}
+
+func (y *YoutubeSpider) saveVideo(ctx context.Context, data *VideoData) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, y.timeout)
+	defer cancel()
+
+	err = y.store.SaveVideo(ctx, data)
+	if err != nil {
+		y.logger.Error(err)
+		return
+	}
+	y.logger.Info("save video success", data.Id)
+
+	return
+}
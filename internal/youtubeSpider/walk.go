@@ -0,0 +1,23 @@
+package youtubeSpider
+
+// walkMaxPage the pages a walk over continuations follows at most, when no max page is configured
+const walkMaxPage = 1000
+
+// walk the continuation tokens followed over the pages of a list, like search results or a playlist.
+// It ends on a page without a token, but also on a page without items, on a token followed before
+// or after walkMaxPage pages, in case the server keeps returning a token
+type walk struct {
+	tokens map[string]bool
+}
+
+// next if the walk goes on to token after a page of n items
+func (w *walk) next(token string, n int) bool {
+	if token == "" || n == 0 || w.tokens[token] || len(w.tokens) >= walkMaxPage {
+		return false
+	}
+	if w.tokens == nil {
+		w.tokens = make(map[string]bool)
+	}
+	w.tokens[token] = true
+	return true
+}
@@ -0,0 +1,400 @@
+package youtubeSpider
+
+import (
+	"fmt"
+	"github.com/lizongying/go-youtube/internal/config"
+	"github.com/lizongying/go-youtube/internal/fakeYoutube"
+	"github.com/lizongying/go-youtube/internal/logger"
+	"github.com/lizongying/go-youtube/internal/proxyPool"
+	"golang.org/x/net/context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestSpider a spider on the fixtures of fakeYoutube, keeping what it crawls in memory
+func newTestSpider(t *testing.T, configure func(c *config.Config)) (server *fakeYoutube.Server, store *MemoryStore, youtubeSpider *YoutubeSpider) {
+	t.Helper()
+
+	server = fakeYoutube.NewServer()
+	t.Cleanup(server.Close)
+
+	c := &config.Config{}
+	server.Configure(c)
+	c.Log.Level = "error"
+	c.Spider.Rules = config.DefaultRules()
+	c.Spider.Retry.MaxAttempts = 3
+	c.Spider.Retry.BaseDelay = time.Millisecond
+	c.Spider.Retry.MaxDelay = time.Millisecond * 10
+	if configure != nil {
+		configure(c)
+	}
+
+	l, err := logger.NewLogger(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pp, err := proxyPool.NewProxyPool(c, l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store = NewMemoryStore()
+	youtubeSpider, err = NewYoutubeSpiderWithStore(c, l, store, pp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func countRequests(requests []string, request string) (n int) {
+	for _, v := range requests {
+		if v == request {
+			n++
+		}
+	}
+	return
+}
+
+func TestSearchPagination(t *testing.T) {
+	server, store, y := newTestSpider(t, nil)
+
+	err := y.Search(context.Background(), MetaSearch{Keyword: "beauty"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	for request, want := range map[string]int{
+		"GET /results": 1,
+		// beauty-2, then beauty-3, which has no token
+		"POST /youtubei/v1/search": 2,
+		// alice is in every page and owns the playlist, she is evaluated once
+		"GET /@alice/videos": 1,
+		"GET /@bob/videos":   1,
+		// carol is only behind a short
+		"POST /youtubei/v1/player": 1,
+		"GET /@carol/videos":       1,
+		// the playlist and its second page
+		"POST /youtubei/v1/browse": 2,
+	} {
+		if n := countRequests(requests, request); n != want {
+			t.Errorf("%s: %d requests, want %d", request, n, want)
+		}
+	}
+
+	if ids := store.Users(); !reflect.DeepEqual(ids, []string{"alice"}) {
+		t.Errorf("users %v, want [alice]", ids)
+	}
+	playlist, ok := store.Playlist("PLbeauty")
+	if !ok {
+		t.Fatal("playlist not saved")
+	}
+	if playlist.ChannelHandle != "alice" || len(playlist.Videos) != 3 {
+		t.Errorf("playlist of %q with %d videos, want alice with 3", playlist.ChannelHandle, len(playlist.Videos))
+	}
+}
+
+func TestSearchApiMaxPage(t *testing.T) {
+	server, _, y := newTestSpider(t, nil)
+
+	err := y.SearchApi(context.Background(), MetaSearch{Keyword: "beauty", NextPageToken: "beauty-2", Page: 1, MaxPage: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countRequests(server.Requests(), "POST /youtubei/v1/search"); n != 1 {
+		t.Errorf("%d search requests, want 1", n)
+	}
+}
+
+func TestSearchApiRepeatedToken(t *testing.T) {
+	server, _, y := newTestSpider(t, nil)
+
+	// beauty-loop answers with itself as the next page, forever
+	err := y.SearchApi(context.Background(), MetaSearch{Keyword: "beauty", NextPageToken: "beauty-loop", Page: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countRequests(server.Requests(), "POST /youtubei/v1/search"); n != 2 {
+		t.Errorf("%d search requests, want 2", n)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	w := new(walk)
+	for i, v := range []struct {
+		token string
+		n     int
+		next  bool
+	}{
+		{"a", 3, true},
+		{"b", 2, true},
+		{"", 2, false},
+		{"c", 0, false},
+		{"a", 3, false},
+	} {
+		if next := w.next(v.token, v.n); next != v.next {
+			t.Errorf("%d %q after %d items: %t, want %t", i, v.token, v.n, next, v.next)
+		}
+	}
+
+	w = new(walk)
+	pages := 0
+	for w.next(fmt.Sprint(pages), 1) {
+		pages++
+	}
+	if pages != walkMaxPage {
+		t.Errorf("%d pages, want %d", pages, walkMaxPage)
+	}
+}
+
+func TestVideosQualification(t *testing.T) {
+	for _, v := range []struct {
+		name  string
+		meta  MetaUser
+		rules *config.Rules
+		saved bool
+	}{
+		{"alice within the default rules", MetaUser{Id: "alice", Key: "UCalice"}, nil, true},
+		{"alice below min followers", MetaUser{Id: "alice", Key: "UCalice"}, &config.Rules{MinFollowers: 20000}, false},
+		{"alice outside the countries", MetaUser{Id: "alice", Key: "UCalice"}, &config.Rules{Countries: []string{"Canada"}}, false},
+		{"bob below the default view avg", MetaUser{Id: "bob", Key: "UCbob"}, nil, false},
+		{"bob without bounds", MetaUser{Id: "bob", Key: "UCbob"}, &config.Rules{}, true},
+		{"carol without an email required", MetaUser{Id: "carol", Key: "UCcarol"}, &config.Rules{RequireEmail: true}, true},
+		// dave's published times are not understood, they do not make him out of date
+		{"dave of unknown activity", MetaUser{Id: "dave", Key: "UCdave"}, nil, true},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			_, store, y := newTestSpider(t, nil)
+
+			err := y.Videos(withRules(context.Background(), v.rules), v.meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := store.User(v.meta.Id); ok != v.saved {
+				t.Errorf("saved %t, want %t", ok, v.saved)
+			}
+		})
+	}
+}
+
+func TestVideosSaved(t *testing.T) {
+	_, store, y := newTestSpider(t, nil)
+
+	err := y.Videos(context.Background(), MetaUser{KeyWord: "beauty", Id: "alice", Key: "UCalice", UserName: "Alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, ok := store.User("alice")
+	if !ok {
+		t.Fatal("alice not saved")
+	}
+	if data.Followers != 12300 || data.VideoCount != 4 || data.ViewAvg10 <= 0 {
+		t.Errorf("followers %d, video count %d, view avg %d", data.Followers, data.VideoCount, data.ViewAvg10)
+	}
+	if data.ViewAvg <= 0 || data.UploadsPerMonth <= 0 {
+		t.Errorf("view avg %d, uploads per month %f", data.ViewAvg, data.UploadsPerMonth)
+	}
+	if data.Email != "alice.beauty@example.com" || data.Country != "United States" || !data.HasBusinessEmail {
+		t.Errorf("email %q, country %q, business email %t", data.Email, data.Country, data.HasBusinessEmail)
+	}
+	if !data.JoinedAt.Equal(time.Date(2014, 3, 3, 0, 0, 0, 0, time.UTC)) || data.TotalViews != 1204381 {
+		t.Errorf("joined %s, total views %d", data.JoinedAt, data.TotalViews)
+	}
+	if len(data.Links) != 3 || len(data.Socials) != 3 {
+		t.Errorf("%d links, %d socials, want 3 and 3", len(data.Links), len(data.Socials))
+	}
+	if !reflect.DeepEqual(data.Keywords, []string{"beauty"}) || data.FirstSeen.IsZero() {
+		t.Errorf("keywords %v, first seen %s", data.Keywords, data.FirstSeen)
+	}
+
+	snapshots, err := y.Growth(context.Background(), "UCalice", time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Followers != 12300 || snapshots[0].VideoCount != 4 {
+		t.Errorf("snapshots %+v, want one of 12300 followers and 4 videos", snapshots)
+	}
+}
+
+func TestVideosContacts(t *testing.T) {
+	_, store, y := newTestSpider(t, nil)
+
+	err := y.Videos(withRules(context.Background(), &config.Rules{}), MetaUser{Id: "carol", Key: "UCcarol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, ok := store.User("carol")
+	if !ok {
+		t.Fatal("carol not saved")
+	}
+	if want := []string{"carol@example.com", "carol.press@example.org"}; !reflect.DeepEqual(data.Emails, want) {
+		t.Errorf("emails %v, want %v", data.Emails, want)
+	}
+	if want := []Social{{SocialInstagram, "carol.tips", "https://www.instagram.com/carol.tips"}}; !reflect.DeepEqual(data.Socials, want) {
+		t.Errorf("socials %v, want %v", data.Socials, want)
+	}
+}
+
+func TestSaveKeepsWhatWasNotFetched(t *testing.T) {
+	server, store, y := newTestSpider(t, nil)
+	ctx := context.Background()
+
+	err := y.Videos(ctx, MetaUser{KeyWord: "beauty", Id: "alice", Key: "UCalice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, _ := store.User("alice")
+
+	// the home tab has no video stats, and the About tab fails this time
+	server.Fail("/@alice/about", http.StatusNotFound, 1)
+	err = y.UserApi(ctx, MetaUser{KeyWord: "makeup", Id: "alice", Key: "UCalice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after, _ := store.User("alice")
+	if after.VideoCount != before.VideoCount || after.ViewAvg != before.ViewAvg || after.UploadsPerMonth != before.UploadsPerMonth {
+		t.Errorf("video stats %d %d %f, want %d %d %f", after.VideoCount, after.ViewAvg, after.UploadsPerMonth,
+			before.VideoCount, before.ViewAvg, before.UploadsPerMonth)
+	}
+	if !reflect.DeepEqual(after.Links, before.Links) || after.Country != before.Country || !after.JoinedAt.Equal(before.JoinedAt) {
+		t.Errorf("about %v %q %s, want %v %q %s", after.Links, after.Country, after.JoinedAt, before.Links, before.Country, before.JoinedAt)
+	}
+	if !reflect.DeepEqual(after.Keywords, []string{"beauty", "makeup"}) {
+		t.Errorf("keywords %v, want [beauty makeup]", after.Keywords)
+	}
+	if !after.FirstSeen.Equal(before.FirstSeen) {
+		t.Errorf("first seen %s, want %s", after.FirstSeen, before.FirstSeen)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	server, store, y := newTestSpider(t, nil)
+
+	// two failures are within the 3 attempts
+	server.Fail("/@alice/videos", http.StatusServiceUnavailable, 2)
+	err := y.Videos(context.Background(), MetaUser{Id: "alice", Key: "UCalice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(server.Requests(), "GET /@alice/videos"); n != 3 {
+		t.Errorf("%d requests, want 3", n)
+	}
+	if _, ok := store.User("alice"); !ok {
+		t.Error("alice not saved")
+	}
+
+	server.Fail("/@bob/videos", http.StatusServiceUnavailable, 3)
+	err = y.Videos(withRules(context.Background(), &config.Rules{}), MetaUser{Id: "bob", Key: "UCbob"})
+	if err == nil {
+		t.Error("no error after 3 failures")
+	}
+	if _, ok := store.User("bob"); ok {
+		t.Error("bob saved")
+	}
+}
+
+func TestDedupFreshness(t *testing.T) {
+	server, store, y := newTestSpider(t, func(c *config.Config) {
+		c.Spider.Dedup.Freshness = time.Hour
+	})
+	ctx := context.Background()
+
+	err := y.SearchApi(ctx, MetaSearch{Keyword: "beauty", NextPageToken: "beauty-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// fresh, the keyword of the later job is added all the same
+	err = y.SearchApi(ctx, MetaSearch{Keyword: "makeup", NextPageToken: "beauty-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(server.Requests(), "GET /@alice/videos"); n != 1 {
+		t.Errorf("%d requests, want 1", n)
+	}
+	data, _ := store.User("alice")
+	if !reflect.DeepEqual(data.Keywords, []string{"beauty", "makeup"}) {
+		t.Errorf("keywords %v, want [beauty makeup]", data.Keywords)
+	}
+
+	// other rules, other evaluation
+	err = y.SearchApi(ctx, MetaSearch{Keyword: "makeup", NextPageToken: "beauty-3", Rules: &config.Rules{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(server.Requests(), "GET /@alice/videos"); n != 2 {
+		t.Errorf("%d requests, want 2", n)
+	}
+}
+
+func TestDedupIncomplete(t *testing.T) {
+	for _, v := range []struct {
+		name  string
+		path  string
+		rules *config.Rules
+	}{
+		{"about for countries", "/@alice/about", &config.Rules{Countries: []string{"United States"}}},
+		{"captions for languages", "/youtubei/v1/player", &config.Rules{Languages: []string{"en"}}},
+	} {
+		t.Run(v.name, func(t *testing.T) {
+			server, store, y := newTestSpider(t, func(c *config.Config) {
+				c.Spider.Dedup.Freshness = time.Hour
+			})
+			ctx := withRules(context.Background(), v.rules)
+			meta := MetaUser{Id: "alice", Key: "UCalice"}
+
+			server.Fail(v.path, http.StatusServiceUnavailable, 3)
+			err := y.evaluate(ctx, meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := store.User("alice"); ok {
+				t.Error("alice saved without what the rules need")
+			}
+			// not judged, a later job evaluates her again
+			if y.skip(ctx, meta) {
+				t.Fatal("alice skipped as evaluated")
+			}
+
+			err = y.evaluate(ctx, meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := store.User("alice"); !ok {
+				t.Error("alice not saved")
+			}
+			if !y.skip(ctx, meta) {
+				t.Error("alice not skipped once evaluated")
+			}
+		})
+	}
+}
+
+func TestSocial(t *testing.T) {
+	for _, v := range []struct {
+		link string
+		ok   bool
+		want Social
+	}{
+		{"https://www.instagram.com/alice.beauty/", true, Social{SocialInstagram, "alice.beauty", "https://www.instagram.com/alice.beauty"}},
+		{"https://www.tiktok.com/@alice.beauty", true, Social{SocialTiktok, "alice.beauty", "https://www.tiktok.com/@alice.beauty"}},
+		{"alice.example.com/shop", true, Social{SocialSite, "alice.example.com", "https://alice.example.com/shop"}},
+		{"https://www.youtube.com/@alice", false, Social{}},
+		{"https://www.instagram.com/p/abc", false, Social{}},
+		{"https://amzn.to/3xyz", false, Social{}},
+		{"https://bit.ly/alice", false, Social{}},
+		{"https://open.spotify.com/show/1", false, Social{}},
+		{"https://alice.myshopify.com", false, Social{}},
+	} {
+		s, ok := social(v.link)
+		if ok != v.ok || s != v.want {
+			t.Errorf("%s: %v %t, want %v %t", v.link, s, ok, v.want, v.ok)
+		}
+	}
+}